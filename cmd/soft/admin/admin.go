@@ -73,5 +73,9 @@ func init() {
 		syncHooksCmd,
 		migrateCmd,
 		rollbackCmd,
+		dbMigrateCmd,
+		fsckCmd,
+		backupCmd,
+		restoreCmd,
 	)
 }
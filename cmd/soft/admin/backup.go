@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/cmd"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/backup"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupOutput string
+
+	backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the server data",
+		Long: `Back up the database, repositories, LFS objects, configuration, and SSH keys
+to a single archive. The server can keep running during the backup.`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx := c.Context()
+			cfg := config.FromContext(ctx)
+
+			output := backupOutput
+			if output == "" {
+				output = fmt.Sprintf("soft-serve-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+			}
+
+			output, err := filepath.Abs(output)
+			if err != nil {
+				return err
+			}
+
+			if rel, err := filepath.Rel(cfg.DataPath, output); err == nil && filepath.IsLocal(rel) {
+				return fmt.Errorf("backup file must be outside of the data path %s", cfg.DataPath)
+			}
+
+			f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return err
+			}
+
+			if err := backup.Backup(ctx, cfg, f); err != nil {
+				f.Close()         // nolint: errcheck
+				os.Remove(output) // nolint: errcheck
+				return fmt.Errorf("backup: %w", err)
+			}
+
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(c.OutOrStdout(), output)
+			return nil
+		},
+	}
+
+	restoreCmd = &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore the server data from a backup",
+		Long: `Restore a backup made with "soft admin backup" into an empty data path.
+The archive checksums are verified, then the database is migrated to the
+current version and the repository hooks are regenerated.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			ctx := c.Context()
+			cfg := config.FromContext(ctx)
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+
+			defer f.Close() // nolint: errcheck
+
+			m, err := backup.Restore(ctx, cfg, f)
+			if err != nil {
+				return fmt.Errorf("restore: %w", err)
+			}
+
+			if err := cmd.InitBackendContext(c, args); err != nil {
+				return err
+			}
+
+			defer cmd.CloseDBContext(c, args) // nolint: errcheck
+
+			ctx = c.Context()
+			if err := migrate.Migrate(ctx, db.FromContext(ctx)); err != nil {
+				return fmt.Errorf("migration: %w", err)
+			}
+
+			if err := cmd.InitializeHooks(ctx, cfg, backend.FromContext(ctx)); err != nil {
+				return fmt.Errorf("initialize hooks: %w", err)
+			}
+
+			fmt.Fprintf(c.OutOrStdout(), "Restored %d files from backup created at %s\n", len(m.Files), m.CreatedAt.Format(time.DateTime))
+			return nil
+		},
+	}
+)
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "backup file path (default \"soft-serve-backup-<timestamp>.tar.gz\")")
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/cmd"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbMigrateDriver string
+	dbMigrateDSN    string
+
+	dbMigrateCmd = &cobra.Command{
+		Use:   "db-migrate",
+		Short: "Copy the database to another database",
+		Long: `Copy every table of the server database to another database, preserving IDs
+and sequences. The target database must be empty, and can use a different
+driver, e.g. to move from SQLite to Postgres.
+
+The server can keep running during the copy, which reads a consistent
+snapshot of the database. Changes made after the copy starts are not copied,
+so stop the server before switching it to the new database.`,
+		Args:               cobra.NoArgs,
+		PersistentPreRunE:  cmd.InitBackendContext,
+		PersistentPostRunE: cmd.CloseDBContext,
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx := c.Context()
+			cfg := config.FromContext(ctx)
+			src := db.FromContext(ctx)
+
+			if dbMigrateDriver == cfg.DB.Driver && dbMigrateDSN == cfg.DB.DataSource {
+				return fmt.Errorf("target database is the server database")
+			}
+
+			version, err := migrate.Version(ctx, src)
+			if err != nil {
+				return err
+			}
+
+			if version != migrate.LatestVersion() {
+				return fmt.Errorf("server database is not up to date, run \"soft admin migrate\" first")
+			}
+
+			dst, err := db.Open(ctx, dbMigrateDriver, dbMigrateDSN)
+			if err != nil {
+				return fmt.Errorf("open target database: %w", err)
+			}
+
+			defer dst.Close() // nolint: errcheck
+
+			version, err = migrate.Version(ctx, dst)
+			if err != nil {
+				return err
+			}
+
+			if version != 0 {
+				return fmt.Errorf("target database is not empty")
+			}
+
+			if err := migrate.Migrate(ctx, dst); err != nil {
+				return fmt.Errorf("migration: %w", err)
+			}
+
+			tables, err := db.Copy(ctx, dst, src)
+			if err != nil {
+				return fmt.Errorf("copy database: %w", err)
+			}
+
+			out := c.OutOrStdout()
+			for _, t := range tables {
+				fmt.Fprintf(out, "%s: %d rows\n", t.Name, t.Rows)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	dbMigrateCmd.Flags().StringVar(&dbMigrateDriver, "to-driver", "", "target database driver (sqlite or postgres)")
+	dbMigrateCmd.Flags().StringVar(&dbMigrateDSN, "to-dsn", "", "target database data source")
+	dbMigrateCmd.MarkFlagRequired("to-driver") // nolint: errcheck
+	dbMigrateCmd.MarkFlagRequired("to-dsn")    // nolint: errcheck
+}
@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/charmbracelet/soft-serve/cmd"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fsckOpts backend.FsckOptions
+
+	fsckCmd = &cobra.Command{
+		Use:   "fsck",
+		Short: "Check the consistency of repositories",
+		Long: `Cross-check the repositories of the database against the repositories on
+disk, run git fsck on every repository, and check that LFS objects are stored.
+
+Use --adopt to add orphaned repository directories to the database and to
+re-initialize missing repositories, or --prune to delete them. Both also
+regenerate missing hooks and stale last-modified times.`,
+		Args:               cobra.NoArgs,
+		PersistentPreRunE:  cmd.InitBackendContext,
+		PersistentPostRunE: cmd.CloseDBContext,
+		RunE: func(c *cobra.Command, _ []string) error {
+			if fsckOpts.Adopt && fsckOpts.Prune {
+				return fmt.Errorf("--adopt and --prune are mutually exclusive")
+			}
+
+			ctx := c.Context()
+			be := backend.FromContext(ctx)
+			problems, err := be.Fsck(ctx, fsckOpts)
+			if err != nil {
+				return err
+			}
+
+			out := c.OutOrStdout()
+			var unfixed int
+			for _, p := range problems {
+				status := ""
+				switch {
+				case p.Fixed:
+					status = " (fixed)"
+				case p.FixErr != nil:
+					status = fmt.Sprintf(" (fix failed: %v)", p.FixErr)
+					unfixed++
+				default:
+					unfixed++
+				}
+
+				fmt.Fprintf(out, "%s: %s: %s%s\n", p.Repo, p.Kind, p.Details, status)
+			}
+
+			if unfixed > 0 {
+				return fmt.Errorf("%d unfixed problems found", unfixed)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	fsckCmd.Flags().BoolVar(&fsckOpts.Adopt, "adopt", false, "adopt orphaned and missing repositories")
+	fsckCmd.Flags().BoolVar(&fsckOpts.Prune, "prune", false, "prune orphaned and missing repositories and lfs objects")
+	fsckCmd.Flags().IntVarP(&fsckOpts.Workers, "workers", "w", runtime.NumCPU(), "number of repositories checked concurrently")
+}
@@ -252,6 +252,11 @@ func (r repository) ID() int64 {
 	return 0
 }
 
+// IsArchived implements proto.Repository.
+func (repository) IsArchived() bool {
+	return false
+}
+
 // IsHidden implements proto.Repository.
 func (repository) IsHidden() bool {
 	return false
@@ -267,6 +272,11 @@ func (repository) IsPrivate() bool {
 	return false
 }
 
+// IsTemplate implements proto.Repository.
+func (repository) IsTemplate() bool {
+	return false
+}
+
 // Name implements proto.Repository.
 func (r repository) Name() string {
 	return filepath.Base(r.r.Path)
@@ -282,6 +292,11 @@ func (r repository) ProjectName() string {
 	return r.Name()
 }
 
+// Topics implements proto.Repository.
+func (repository) Topics() []string {
+	return nil
+}
+
 // UpdatedAt implements proto.Repository.
 func (r repository) UpdatedAt() time.Time {
 	t, err := r.r.LatestCommitTime()
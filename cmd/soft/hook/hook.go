@@ -102,11 +102,13 @@ var (
 				return fmt.Errorf("invalid update hook input: %s", args)
 			}
 
-			hks.Update(ctx, stdout, stderr, repoName, hooks.HookArg{
+			if err := hks.Update(ctx, stdout, stderr, repoName, hooks.HookArg{
 				RefName: args[0],
 				OldSha:  args[1],
 				NewSha:  args[2],
-			})
+			}); err != nil {
+				return err
+			}
 		case hooks.PostUpdateHook:
 			hks.PostUpdate(ctx, stdout, stderr, repoName, args...)
 		}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aymanbagabas/git-module"
+)
+
+// ArchiveFormat is the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	// ArchiveTarGz is a gzip compressed tar archive.
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	// ArchiveZip is a zip archive.
+	ArchiveZip ArchiveFormat = "zip"
+)
+
+// ContentType returns the MIME type of the archive format.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveZip:
+		return "application/zip"
+	default:
+		return "application/gzip"
+	}
+}
+
+// ResolveCommit resolves the given revision to a commit hash in the
+// repository at the given path.
+func ResolveCommit(ctx context.Context, path string, rev string) (string, error) {
+	if !isGitDir(path) {
+		return "", ErrNotAGitRepository
+	}
+
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", ErrRevisionNotExist
+	}
+
+	cmd := git.NewCommand("rev-parse", "--verify", "--quiet", rev+"^{commit}").WithContext(ctx)
+	out, err := cmd.RunInDir(path)
+	if err != nil {
+		return "", ErrRevisionNotExist
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Archive writes an archive of the given commit in the repository at the
+// given path to w. Every file in the archive is placed under prefix.
+func Archive(ctx context.Context, path string, w io.Writer, format ArchiveFormat, prefix string, commit string) error {
+	if !isGitDir(path) {
+		return ErrNotAGitRepository
+	}
+
+	var stderr bytes.Buffer
+	cmd := git.NewCommand(
+		"archive",
+		"--format="+string(format),
+		"--prefix="+prefix+"/",
+		commit,
+	).WithContext(ctx).WithTimeout(-1)
+	if err := cmd.RunInDirWithOptions(path, git.RunInDirOptions{
+		Stdout: w,
+		Stderr: &stderr,
+	}); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
@@ -151,6 +151,57 @@ func (r *Repository) Diff(commit *Commit) (*Diff, error) {
 	return toDiff(diff), nil
 }
 
+// FileChanges are the paths of the files changed by a commit.
+type FileChanges struct {
+	Added    []string
+	Modified []string
+	Removed  []string
+}
+
+// FileChanges returns the paths of the files added, modified, and removed by
+// a commit, compared to its first parent. Unlike Diff, it doesn't generate
+// patches and isn't limited to DiffMaxFiles. Renamed files are reported as
+// removed from their old path and added to their new one.
+func (r *Repository) FileChanges(commit *Commit) (FileChanges, error) {
+	args := []string{"diff-tree", "--no-commit-id", "--name-status", "-r", "-z"}
+	if commit.ParentsCount() > 0 {
+		parent, err := commit.ParentID(0)
+		if err != nil {
+			return FileChanges{}, err
+		}
+		args = append(args, parent.String(), commit.ID.String())
+	} else {
+		args = append(args, "--root", commit.ID.String())
+	}
+
+	out, err := NewCommand(args...).RunInDir(r.Path)
+	if err != nil {
+		return FileChanges{}, err
+	}
+
+	return parseNameStatus(out), nil
+}
+
+// parseNameStatus parses the NUL separated output of diff-tree --name-status,
+// where each status is followed by a path.
+func parseNameStatus(out []byte) FileChanges {
+	var fc FileChanges
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		status, path := fields[i], fields[i+1]
+		switch status {
+		case "A":
+			fc.Added = append(fc.Added, path)
+		case "D":
+			fc.Removed = append(fc.Removed, path)
+		default:
+			fc.Modified = append(fc.Modified, path)
+		}
+	}
+
+	return fc
+}
+
 // Patch returns the patch for the given reference.
 func (r *Repository) Patch(commit *Commit) (string, error) {
 	diff, err := r.Diff(commit)
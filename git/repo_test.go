@@ -0,0 +1,23 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNameStatus(t *testing.T) {
+	out := []byte("A\x00new file.txt\x00M\x00README.md\x00T\x00link\x00D\x00old/\"quoted\".txt\x00")
+	want := FileChanges{
+		Added:    []string{"new file.txt"},
+		Modified: []string{"README.md", "link"},
+		Removed:  []string{`old/"quoted".txt`},
+	}
+
+	if got := parseNameStatus(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNameStatus() = %+v, want %+v", got, want)
+	}
+
+	if got := parseNameStatus(nil); !reflect.DeepEqual(got, FileChanges{}) {
+		t.Errorf("parseNameStatus(nil) = %+v, want no changes", got)
+	}
+}
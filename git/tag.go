@@ -1,6 +1,151 @@
 package git
 
-import "github.com/aymanbagabas/git-module"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/git-module"
+)
 
 // Tag is a git tag.
 type Tag = git.Tag
+
+// SignatureFormat is the format of a tag or commit signature.
+type SignatureFormat string
+
+const (
+	// SignatureFormatGPG is an OpenPGP signature.
+	SignatureFormatGPG SignatureFormat = "gpg"
+	// SignatureFormatSSH is an SSH signature.
+	SignatureFormatSSH SignatureFormat = "ssh"
+	// SignatureFormatX509 is an X.509 (S/MIME) signature.
+	SignatureFormatX509 SignatureFormat = "x509"
+)
+
+var signatureHeaders = map[string]SignatureFormat{
+	"-----BEGIN PGP SIGNATURE-----":  SignatureFormatGPG,
+	"-----BEGIN SSH SIGNATURE-----":  SignatureFormatSSH,
+	"-----BEGIN SIGNED MESSAGE-----": SignatureFormatX509,
+}
+
+// SplitTagSignature splits an annotated tag message into the message and the
+// format of its trailing signature. The format is empty if the tag isn't
+// signed.
+func SplitTagSignature(message string) (string, SignatureFormat) {
+	for header, format := range signatureHeaders {
+		if strings.HasPrefix(message, header) {
+			return "", format
+		}
+		if i := strings.Index(message, "\n"+header); i >= 0 {
+			return message[:i+1], format
+		}
+	}
+
+	return message, ""
+}
+
+// AnnotatedTag is an annotated tag object.
+type AnnotatedTag struct {
+	// ID is the tag object ID.
+	ID string
+	// Name is the tag name.
+	Name string
+	// Target is the ID of the commit the tag points to.
+	Target string
+	// Tagger is the tag creator.
+	Tagger *git.Signature
+	// Message is the tag message without its signature.
+	Message string
+	// Signature is the signature format, empty if the tag isn't signed.
+	Signature SignatureFormat
+}
+
+// AnnotatedTag returns the annotated tag object with the given ID. It returns
+// nil if the object isn't an annotated tag, e.g. the commit of a lightweight
+// tag.
+func (r *Repository) AnnotatedTag(id string) (*AnnotatedTag, error) {
+	typ, err := r.CatFileType(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != git.ObjectTag {
+		return nil, nil
+	}
+
+	data, err := NewCommand("cat-file", "tag", id).RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := parseAnnotatedTag(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := r.RevParse(id + "^{commit}")
+	if err != nil {
+		return nil, err
+	}
+
+	tag.ID = id
+	tag.Target = strings.TrimSpace(target)
+
+	return tag, nil
+}
+
+// parseAnnotatedTag parses the headers and message of a tag object.
+func parseAnnotatedTag(data string) (*AnnotatedTag, error) {
+	tag := &AnnotatedTag{}
+	headers, message, _ := strings.Cut(data, "\n\n")
+	for _, line := range strings.Split(headers, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "tag":
+			tag.Name = value
+		case "tagger":
+			sig, err := parseSignature(value)
+			if err != nil {
+				return nil, err
+			}
+			tag.Tagger = sig
+		}
+	}
+
+	tag.Message, tag.Signature = SplitTagSignature(message)
+
+	return tag, nil
+}
+
+// parseSignature parses a "Name <email> timestamp timezone" signature line.
+func parseSignature(line string) (*git.Signature, error) {
+	emailStart := strings.IndexByte(line, '<')
+	emailEnd := strings.IndexByte(line, '>')
+	if emailStart < 0 || emailEnd < emailStart {
+		return nil, fmt.Errorf("invalid signature: %q", line)
+	}
+
+	sig := &git.Signature{
+		Name:  strings.TrimSpace(line[:emailStart]),
+		Email: line[emailStart+1 : emailEnd],
+	}
+
+	fields := strings.Fields(line[emailEnd+1:])
+	if len(fields) > 0 {
+		secs, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature timestamp: %w", err)
+		}
+
+		sig.When = time.Unix(secs, 0)
+		if len(fields) > 1 {
+			if tz, err := time.Parse("-0700", fields[1]); err == nil {
+				sig.When = sig.When.In(tz.Location())
+			}
+		}
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitTagSignature(t *testing.T) {
+	cases := []struct {
+		name    string
+		message string
+		want    string
+		format  SignatureFormat
+	}{
+		{
+			name:    "unsigned",
+			message: "v1.0.0\n",
+			want:    "v1.0.0\n",
+		},
+		{
+			name:    "gpg",
+			message: "v1.0.0\n-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n",
+			want:    "v1.0.0\n",
+			format:  SignatureFormatGPG,
+		},
+		{
+			name:    "ssh",
+			message: "v1.0.0\n\nnotes\n-----BEGIN SSH SIGNATURE-----\nabc\n-----END SSH SIGNATURE-----\n",
+			want:    "v1.0.0\n\nnotes\n",
+			format:  SignatureFormatSSH,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msg, format := SplitTagSignature(c.message)
+			if msg != c.want {
+				t.Errorf("message = %q, want %q", msg, c.want)
+			}
+			if format != c.format {
+				t.Errorf("format = %q, want %q", format, c.format)
+			}
+		})
+	}
+}
+
+func TestParseAnnotatedTag(t *testing.T) {
+	data := "object 0123456789abcdef0123456789abcdef01234567\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger Alice <alice@example.com> 1700000000 +0100\n" +
+		"\n" +
+		"Release v1.0.0\n" +
+		"-----BEGIN PGP SIGNATURE-----\n" +
+		"abc\n" +
+		"-----END PGP SIGNATURE-----\n"
+
+	tag, err := parseAnnotatedTag(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tag.Name != "v1.0.0" {
+		t.Errorf("name = %q, want %q", tag.Name, "v1.0.0")
+	}
+	if tag.Message != "Release v1.0.0\n" {
+		t.Errorf("message = %q", tag.Message)
+	}
+	if tag.Signature != SignatureFormatGPG {
+		t.Errorf("signature = %q, want %q", tag.Signature, SignatureFormatGPG)
+	}
+	if tag.Tagger == nil || tag.Tagger.Name != "Alice" || tag.Tagger.Email != "alice@example.com" {
+		t.Fatalf("tagger = %+v", tag.Tagger)
+	}
+	if !tag.Tagger.When.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("when = %v", tag.Tagger.When)
+	}
+	if _, off := tag.Tagger.When.Zone(); off != 3600 {
+		t.Errorf("zone offset = %d, want 3600", off)
+	}
+}
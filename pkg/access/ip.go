@@ -0,0 +1,112 @@
+package access
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// ErrInvalidCIDR is returned when an invalid CIDR or IP address is provided.
+var ErrInvalidCIDR = errors.New("invalid CIDR")
+
+// IPRules is a set of CIDR based allow and deny rules.
+//
+// An address is allowed when it doesn't match any deny rule and, if there are
+// any allow rules, it matches at least one of them.
+type IPRules struct {
+	Allow []*net.IPNet
+	Deny  []*net.IPNet
+}
+
+// ParseCIDR parses a CIDR string. A plain IP address is treated as a single
+// host network.
+func ParseCIDR(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, ErrInvalidCIDR
+		}
+
+		bits := 8 * net.IPv4len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		} else {
+			bits = 8 * net.IPv6len
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, ErrInvalidCIDR
+	}
+
+	return n, nil
+}
+
+// ParseIPRules parses the given allow and deny CIDR lists.
+func ParseIPRules(allow, deny []string) (IPRules, error) {
+	var rules IPRules
+	for _, s := range allow {
+		n, err := ParseCIDR(s)
+		if err != nil {
+			return IPRules{}, err
+		}
+		rules.Allow = append(rules.Allow, n)
+	}
+
+	for _, s := range deny {
+		n, err := ParseCIDR(s)
+		if err != nil {
+			return IPRules{}, err
+		}
+		rules.Deny = append(rules.Deny, n)
+	}
+
+	return rules, nil
+}
+
+// Allowed returns true if the given IP address is allowed by the rules.
+// A nil IP is only allowed when there are no rules at all.
+func (r IPRules) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return len(r.Allow) == 0 && len(r.Deny) == 0
+	}
+
+	for _, n := range r.Deny {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(r.Allow) == 0 {
+		return true
+	}
+
+	for _, n := range r.Allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddrIP returns the IP address of a network address string. The address can
+// be in the form "host:port" or a plain IP address. It returns nil if the
+// address can't be parsed.
+func AddrIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	// Strip IPv6 zones.
+	if i := strings.LastIndexByte(host, '%'); i >= 0 {
+		host = host[:i]
+	}
+
+	return net.ParseIP(host)
+}
@@ -0,0 +1,65 @@
+package access
+
+import "testing"
+
+func TestParseCIDR(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{"10.0.0.0/8", "10.0.0.0/8", false},
+		{"192.168.1.7", "192.168.1.7/32", false},
+		{"fd00::/8", "fd00::/8", false},
+		{"::1", "::1/128", false},
+		{"", "", true},
+		{"foo", "", true},
+		{"10.0.0.0/33", "", true},
+	}
+
+	for _, c := range cases {
+		n, err := ParseCIDR(c.in)
+		if c.err {
+			if err == nil {
+				t.Errorf("ParseCIDR(%q) => %v, want error", c.in, n)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseCIDR(%q) => unexpected error %v", c.in, err)
+			continue
+		}
+		if n.String() != c.out {
+			t.Errorf("ParseCIDR(%q) => %s, want %s", c.in, n, c.out)
+		}
+	}
+}
+
+func TestIPRulesAllowed(t *testing.T) {
+	rules, err := ParseIPRules([]string{"10.0.0.0/8", "192.168.1.0/24"}, []string{"10.1.0.0/16"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		addr    string
+		allowed bool
+	}{
+		{"10.2.3.4:1234", true},
+		{"10.1.3.4:1234", false},
+		{"192.168.1.20", true},
+		{"192.168.2.20:22", false},
+		{"[::1]:22", false},
+		{"invalid", false},
+	}
+
+	for _, c := range cases {
+		if got := rules.Allowed(AddrIP(c.addr)); got != c.allowed {
+			t.Errorf("Allowed(%q) => %t, want %t", c.addr, got, c.allowed)
+		}
+	}
+
+	if !(IPRules{}).Allowed(AddrIP("127.0.0.1:22")) {
+		t.Errorf("empty rules should allow all addresses")
+	}
+}
@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PruneArchiveCache removes the cached archives older than the archive cache
+// retention period, and the directories they leave empty. It returns the
+// number of removed archives.
+func (d *Backend) PruneArchiveCache(ctx context.Context) (int, error) {
+	root := d.archivesPath()
+	expiry := time.Now().AddDate(0, 0, -d.cfg.ArchiveCache.Retention)
+
+	var n int
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			// Nothing was cached yet, or the archives of a deleted repository
+			// were removed concurrently.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if e.IsDir() {
+			if path != root {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+
+		fi, err := e.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if fi.ModTime().After(expiry) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		n++
+		return nil
+	})
+
+	// Remove the empty directories, deepest first. Directories that still
+	// hold archives fail to be removed and are kept.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i]) // nolint: errcheck
+	}
+
+	return n, err
+}
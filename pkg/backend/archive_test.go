@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func TestPruneArchiveCache(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataPath = t.TempDir()
+	d := &Backend{cfg: cfg}
+
+	// Nothing was cached yet.
+	n, err := d.PruneArchiveCache(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("pruned %d archives, want 0", n)
+	}
+
+	old := filepath.Join(d.archivesPath(), "repo1.git", "abc", "repo1-v0.1.0.zip")
+	recent := filepath.Join(d.archivesPath(), "repo2.git", "def", "repo2-v0.2.0.zip")
+	for _, p := range []string{old, recent} {
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("archive"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expired := time.Now().AddDate(0, 0, -cfg.ArchiveCache.Retention-1)
+	if err := os.Chtimes(old, expired, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err = d.PruneArchiveCache(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("pruned %d archives, want 1", n)
+	}
+
+	if _, err := os.Stat(filepath.Join(d.archivesPath(), "repo1.git")); !os.IsNotExist(err) {
+		t.Errorf("expected the empty directories to be removed, got %v", err)
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("expected the recent archive to be kept, got %v", err)
+	}
+}
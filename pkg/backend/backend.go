@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"sync"
 
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -20,6 +21,9 @@ type Backend struct {
 	logger  *log.Logger
 	cache   *cache
 	manager *task.Manager
+
+	// maintaining are the repositories being maintained.
+	maintaining sync.Map
 }
 
 // New returns a new Soft Serve backend.
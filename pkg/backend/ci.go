@@ -0,0 +1,392 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/ci"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+const (
+	// CIStatusContext is the context of the commit statuses of CI runs.
+	CIStatusContext = "soft-serve/ci"
+
+	// ciRunsLimit is the maximum number of CI runs listed per repository.
+	ciRunsLimit = 50
+
+	// ciLogPollInterval is the interval between log reads when following
+	// the log of a CI run.
+	ciLogPollInterval = 500 * time.Millisecond
+)
+
+// ciLogsPath returns the path to the CI run logs of a repository.
+func (d *Backend) ciLogsPath(repoID int64) string {
+	return filepath.Join(d.cfg.DataPath, "ci", strconv.FormatInt(repoID, 10))
+}
+
+// ciLogPath returns the path to the log of a CI run.
+func (d *Backend) ciLogPath(repoID int64, runID int64) string {
+	return filepath.Join(d.ciLogsPath(repoID), strconv.FormatInt(runID, 10)+".log")
+}
+
+// CIRuns returns the latest CI runs of a repository, newest first.
+func (d *Backend) CIRuns(ctx context.Context, repo string) ([]models.CIRun, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []models.CIRun
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		runs, err = d.store.GetCIRunsByRepoID(ctx, tx, r.ID(), ciRunsLimit)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return runs, nil
+}
+
+// CIRun returns a CI run of a repository.
+func (d *Backend) CIRun(ctx context.Context, repo string, id int64) (models.CIRun, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.CIRun{}, err
+	}
+
+	var run models.CIRun
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		run, err = d.store.GetCIRunByID(ctx, tx, r.ID(), id)
+		return err
+	}); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.CIRun{}, proto.ErrCIRunNotFound
+		}
+		return models.CIRun{}, db.WrapError(err)
+	}
+
+	return run, nil
+}
+
+// QueueCIRun queues a CI run of a repository revision. An empty revision
+// runs the pipeline of the default branch.
+func (d *Backend) QueueCIRun(ctx context.Context, repo string, rev string) (int64, error) {
+	if !d.cfg.CI.Enabled {
+		return 0, proto.ErrCIDisabled
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	rr, err := r.Open()
+	if err != nil {
+		return 0, err
+	}
+
+	ref := rev
+	if rev == "" {
+		head, err := rr.HEAD()
+		if err != nil {
+			return 0, err
+		}
+
+		ref, rev = head.Name().String(), head.ID
+	} else if _, err := rr.ShowRefVerify(git.RefsHeads + rev); err == nil {
+		ref = git.RefsHeads + rev
+	}
+
+	sha, err := git.ResolveCommit(ctx, rr.Path, rev)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.queueCIRun(ctx, proto.UserFromContext(ctx), r, ref, sha)
+}
+
+// queueCIRuns queues CI runs of the branches updated by a push. Anonymous
+// pushes, e.g. over the Git daemon, don't run pipelines.
+func (d *Backend) queueCIRuns(ctx context.Context, w io.Writer, repo string, args []hooks.HookArg) {
+	user, err := d.hookUser(ctx)
+	if err != nil {
+		d.logger.Error("error finding user", "repo", repo, "err", err)
+		return
+	}
+
+	if user == nil {
+		d.logger.Debug("skipping ci runs of anonymous push", "repo", repo)
+		return
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", repo, "err", err)
+		return
+	}
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg.RefName, git.RefsHeads) || git.IsZeroHash(arg.NewSha) {
+			continue
+		}
+
+		id, err := d.queueCIRun(ctx, user, r, arg.RefName, arg.NewSha)
+		switch {
+		case errors.Is(err, ci.ErrNoConfig):
+			continue
+		case err != nil:
+			d.logger.Error("error queueing ci run", "repo", repo, "ref", arg.RefName, "err", err)
+			continue
+		}
+
+		fmt.Fprintf(w, "CI run #%d queued for %s\n", id, arg.RefName) // nolint: errcheck
+	}
+}
+
+// queueCIRun queues a CI run of a repository commit. It returns
+// ci.ErrNoConfig when the commit doesn't have a pipeline configuration.
+func (d *Backend) queueCIRun(ctx context.Context, user proto.User, r proto.Repository, ref string, sha string) (int64, error) {
+	if _, err := ciConfig(r, sha); err != nil {
+		return 0, err
+	}
+
+	var userID int64
+	if user != nil {
+		userID = user.ID()
+	}
+
+	var id int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		id, err = d.store.QueueCIRun(ctx, tx, r.ID(), userID, sha, ref)
+		return err
+	}); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	d.logger.Info("queued ci run", "repo", r.Name(), "ref", ref, "sha", sha, "run", id)
+	d.setCIStatus(proto.WithUserContext(ctx, user), r, sha, proto.CommitStatusPending, fmt.Sprintf("Run #%d queued", id))
+
+	return id, nil
+}
+
+// RunCIRun runs a queued CI run in the task manager and waits for it to
+// finish. Runs that were already started are skipped.
+//
+// The pipeline of the run commit is run in a temporary worktree and its
+// output is written to the run log. The run result is stored as a commit
+// status of the commit.
+func (d *Backend) RunCIRun(ctx context.Context, run models.CIRun) error {
+	var repom models.Repo
+	var started bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		repom, err = d.store.GetRepoByID(ctx, tx, run.RepoID)
+		if err != nil {
+			return err
+		}
+
+		started, err = d.store.StartCIRun(ctx, tx, run.ID)
+		return err
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	if !started {
+		return nil
+	}
+
+	tid := fmt.Sprintf("ci:%d", run.ID)
+	done := make(chan error, 1)
+	d.manager.Add(tid, func(ctx context.Context) error {
+		return d.runCI(ctx, repom.Name, run)
+	})
+
+	d.logger.Info("running ci", "repo", repom.Name, "run", run.ID)
+	d.manager.Run(tid, done)
+
+	return <-done
+}
+
+// runCI runs the pipeline of a CI run and records its result.
+func (d *Backend) runCI(ctx context.Context, repo string, run models.CIRun) error {
+	status := ci.StatusError
+	description := "Errored"
+	defer func() {
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.FinishCIRun(ctx, tx, run.ID, string(status))
+		}); err != nil {
+			d.logger.Error("error finishing ci run", "repo", repo, "run", run.ID, "err", err)
+		}
+	}()
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.setCIStatus(ctx, r, run.CommitSHA, proto.CommitStatusPending, fmt.Sprintf("Run #%d running", run.ID))
+	defer func() {
+		state := proto.CommitStatusState(status)
+		d.setCIStatus(ctx, r, run.CommitSHA, state, fmt.Sprintf("Run #%d %s", run.ID, description))
+	}()
+
+	if err := os.MkdirAll(d.ciLogsPath(r.ID()), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(d.ciLogPath(r.ID(), run.ID))
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	cfg, err := ciConfig(r, run.CommitSHA)
+	if err != nil {
+		fmt.Fprintf(f, "error: %v\n", err) // nolint: errcheck
+		return err
+	}
+
+	rr, err := r.Open()
+	if err != nil {
+		return err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, time.Duration(d.cfg.CI.Timeout)*time.Second)
+	defer cancel()
+
+	err = ci.Run(tctx, ci.Options{
+		ID:       run.ID,
+		Repo:     r.Name(),
+		RepoPath: rr.Path,
+		Commit:   run.CommitSHA,
+		Ref:      run.Ref,
+		Config:   cfg,
+		Runner:   d.cfg.CI.Runner,
+	}, f)
+
+	var stepErr *ci.StepError
+	switch {
+	case err == nil:
+		status, description = ci.StatusSuccess, "passed"
+		fmt.Fprintln(f, "==> passed") // nolint: errcheck
+		return nil
+	case errors.As(err, &stepErr):
+		status, description = ci.StatusFailure, "failed at "+stepErr.Step
+		fmt.Fprintf(f, "==> failed at %s: %v\n", stepErr.Step, stepErr.Err) // nolint: errcheck
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		description = "timed out"
+		fmt.Fprintln(f, "==> timed out") // nolint: errcheck
+		return err
+	default:
+		fmt.Fprintf(f, "error: %v\n", err) // nolint: errcheck
+		return err
+	}
+}
+
+// setCIStatus sets the CI commit status of a commit.
+func (d *Backend) setCIStatus(ctx context.Context, r proto.Repository, sha string, state proto.CommitStatusState, description string) {
+	if _, err := d.SetCommitStatus(ctx, r.Name(), sha, state, CIStatusContext, "", description); err != nil {
+		d.logger.Error("error setting ci commit status", "repo", r.Name(), "sha", sha, "err", err)
+	}
+}
+
+// CIRunLog writes the log of a CI run to w. When follow is true, it keeps
+// writing the log until the run finishes.
+func (d *Backend) CIRunLog(ctx context.Context, repo string, id int64, w io.Writer, follow bool) error {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	path := d.ciLogPath(r.ID(), id)
+	var offset int64
+	for {
+		// Check the run status before reading the log so that the log is
+		// complete when the run is done.
+		run, err := d.CIRun(ctx, repo, id)
+		if err != nil {
+			return err
+		}
+
+		n, err := copyLog(path, offset, w)
+		if err != nil {
+			return err
+		}
+
+		offset += n
+		if !follow || ci.Status(run.Status).Done() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ciLogPollInterval):
+		}
+	}
+}
+
+// copyLog copies a log file starting at offset to w. Missing logs are empty.
+func copyLog(path string, offset int64, w io.Writer) (int64, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.Copy(w, f)
+}
+
+// ciConfig returns the CI pipeline configuration of a repository commit.
+func ciConfig(r proto.Repository, sha string) (*ci.Config, error) {
+	rr, err := r.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := rr.LsTree(sha)
+	if err != nil {
+		return nil, err
+	}
+
+	te, err := tree.TreeEntry(ci.ConfigPath)
+	if err != nil || te.Type() != "blob" {
+		return nil, ci.ErrNoConfig
+	}
+
+	data, err := te.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return ci.ParseConfig(data)
+}
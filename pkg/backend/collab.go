@@ -45,7 +45,7 @@ func (d *Backend) AddCollaborator(ctx context.Context, repo string, username str
 		return err
 	}
 
-	return webhook.SendEvent(ctx, wh)
+	return d.SendEvent(ctx, wh)
 }
 
 // Collaborators returns a list of collaborators for a repository.
@@ -118,5 +118,5 @@ func (d *Backend) RemoveCollaborator(ctx context.Context, repo string, username
 		return err
 	}
 
-	return webhook.SendEvent(ctx, wh)
+	return d.SendEvent(ctx, wh)
 }
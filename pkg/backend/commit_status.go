@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// DefaultCommitStatusContext is the context of commit statuses that don't
+// specify one.
+const DefaultCommitStatusContext = "default"
+
+// resolveCommit resolves a revision of a repository to a commit SHA.
+func resolveCommit(ctx context.Context, r proto.Repository, rev string) (string, error) {
+	rr, err := r.Open()
+	if err != nil {
+		return "", err
+	}
+
+	return git.ResolveCommit(ctx, rr.Path, rev)
+}
+
+// CommitStatuses returns the commit SHA of a repository revision and the
+// statuses of the commit, one per context.
+func (d *Backend) CommitStatuses(ctx context.Context, repo string, rev string) (string, []models.CommitStatus, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sha, err := resolveCommit(ctx, r, rev)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var statuses []models.CommitStatus
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		statuses, err = d.store.GetCommitStatusesByRepoIDAndSHA(ctx, tx, r.ID(), sha)
+		return err
+	}); err != nil {
+		return "", nil, db.WrapError(err)
+	}
+
+	return sha, statuses, nil
+}
+
+// CommitStatusStates returns the combined status state of the given commits
+// of a repository. Commits without statuses are omitted.
+func (d *Backend) CommitStatusStates(ctx context.Context, repo string, shas []string) (map[string]proto.CommitStatusState, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []models.CommitStatus
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		statuses, err = d.store.GetCommitStatusesByRepoIDAndSHAs(ctx, tx, r.ID(), shas)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	states := map[string][]proto.CommitStatusState{}
+	for _, s := range statuses {
+		states[s.CommitSHA] = append(states[s.CommitSHA], proto.CommitStatusState(s.State))
+	}
+
+	combined := make(map[string]proto.CommitStatusState, len(states))
+	for sha, st := range states {
+		combined[sha] = proto.CombinedCommitStatusState(st...)
+	}
+
+	return combined, nil
+}
+
+// SetCommitStatus creates or updates the status of a repository commit for a
+// context. The revision is resolved to a commit SHA first.
+func (d *Backend) SetCommitStatus(ctx context.Context, repo string, rev string, state proto.CommitStatusState, statusContext string, targetURL string, description string) (models.CommitStatus, error) {
+	if _, err := proto.ParseCommitStatusState(string(state)); err != nil {
+		return models.CommitStatus{}, err
+	}
+
+	statusContext = strings.TrimSpace(statusContext)
+	if statusContext == "" {
+		statusContext = DefaultCommitStatusContext
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.CommitStatus{}, err
+	}
+
+	sha, err := resolveCommit(ctx, r, rev)
+	if err != nil {
+		return models.CommitStatus{}, err
+	}
+
+	var userID int64
+	user := proto.UserFromContext(ctx)
+	if user != nil {
+		userID = user.ID()
+	}
+
+	var status models.CommitStatus
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetCommitStatus(ctx, tx, r.ID(), userID, sha, statusContext, string(state), targetURL, description); err != nil {
+			return err
+		}
+
+		statuses, err := d.store.GetCommitStatusesByRepoIDAndSHA(ctx, tx, r.ID(), sha)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			if s.Context == statusContext {
+				status = s
+				break
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return models.CommitStatus{}, db.WrapError(err)
+	}
+
+	wh, err := webhook.NewStatusEvent(ctx, user, r, status)
+	if err != nil {
+		return models.CommitStatus{}, err
+	}
+
+	return status, d.SendEvent(ctx, wh)
+}
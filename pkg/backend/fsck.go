@@ -0,0 +1,404 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/lfs"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sync"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// FsckKind is the kind of a repository consistency problem.
+type FsckKind string
+
+const (
+	// FsckOrphanedRepo is a repository directory without a database record.
+	FsckOrphanedRepo FsckKind = "orphaned repository"
+	// FsckMissingRepo is a repository database record without a directory.
+	FsckMissingRepo FsckKind = "missing repository"
+	// FsckMissingHooks is a repository without the Soft Serve hooks.
+	FsckMissingHooks FsckKind = "missing hooks"
+	// FsckStaleLastModified is a repository with a last-modified time that
+	// doesn't match its latest commit.
+	FsckStaleLastModified FsckKind = "stale last-modified"
+	// FsckCorruptRepo is a repository that fails git fsck.
+	FsckCorruptRepo FsckKind = "corrupt repository"
+	// FsckMissingLFSObject is an LFS object database record without a
+	// stored object.
+	FsckMissingLFSObject FsckKind = "missing lfs object"
+)
+
+// FsckOptions are the options of a repository consistency check.
+type FsckOptions struct {
+	// Workers is the number of repositories checked concurrently.
+	Workers int
+	// Adopt adds orphaned repository directories to the database, and
+	// re-initializes missing repositories as empty repositories.
+	Adopt bool
+	// Prune deletes orphaned repository directories, and the database
+	// records of missing repositories and LFS objects.
+	Prune bool
+}
+
+// FsckProblem is a repository consistency problem.
+type FsckProblem struct {
+	// Repo is the repository name.
+	Repo string
+	// Kind is the kind of problem.
+	Kind FsckKind
+	// Details describes the problem.
+	Details string
+	// Fixed is whether the problem was fixed.
+	Fixed bool
+	// FixErr is the error that happened while fixing the problem.
+	FixErr error
+}
+
+// fixable returns whether any fix is enabled.
+func (o FsckOptions) fixable() bool {
+	return o.Adopt || o.Prune
+}
+
+// Fsck cross-checks the repositories of the database against the
+// repositories on disk, runs git fsck on every repository, and checks that
+// their LFS objects are stored.
+//
+// Missing hooks and stale last-modified times are fixed when adopting or
+// pruning. Corrupt repositories are never fixed.
+func (d *Backend) Fsck(ctx context.Context, opts FsckOptions) ([]FsckProblem, error) {
+	var repos []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		repos, err = d.store.GetAllRepos(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	dirs, err := d.repoDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var mu gosync.Mutex
+	var problems []FsckProblem
+	report := func(p FsckProblem) {
+		mu.Lock()
+		defer mu.Unlock()
+		problems = append(problems, p)
+	}
+
+	known := map[string]bool{}
+	wq := sync.NewWorkPool(ctx, opts.Workers, sync.WithWorkPoolLogger(d.logger.Errorf))
+	for _, r := range repos {
+		r := r
+		known[r.Name] = true
+		wq.Add(r.Name, func() {
+			d.fsckRepo(ctx, r, dirs[r.Name], opts, report)
+		})
+	}
+
+	for name := range dirs {
+		if known[name] {
+			continue
+		}
+
+		name := name
+		wq.Add(name, func() {
+			d.fsckOrphanedRepo(ctx, name, opts, report)
+		})
+	}
+
+	wq.Run()
+
+	// Problems of a repository are reported in order by a single worker.
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Repo < problems[j].Repo
+	})
+
+	return problems, ctx.Err()
+}
+
+// repoDirs returns the names of the repositories on disk.
+func (d *Backend) repoDirs() (map[string]bool, error) {
+	dirs := map[string]bool{}
+	root := d.reposPath()
+	err := filepath.WalkDir(root, func(p string, de fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !de.IsDir() || !strings.HasSuffix(de.Name(), ".git") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		dirs[utils.SanitizeRepo(filepath.ToSlash(rel))] = true
+		return filepath.SkipDir
+	})
+
+	return dirs, err
+}
+
+// fsckRepo checks a repository of the database.
+func (d *Backend) fsckRepo(ctx context.Context, r models.Repo, exists bool, opts FsckOptions, report func(FsckProblem)) {
+	if !exists {
+		p := FsckProblem{
+			Repo:    r.Name,
+			Kind:    FsckMissingRepo,
+			Details: "repository directory not found",
+		}
+
+		switch {
+		case opts.Adopt:
+			p.FixErr = d.reinitRepo(ctx, r)
+			p.Fixed = p.FixErr == nil
+		case opts.Prune:
+			p.FixErr = d.pruneRepo(ctx, r)
+			p.Fixed = p.FixErr == nil
+		}
+
+		report(p)
+		if !p.Fixed || opts.Prune {
+			return
+		}
+	}
+
+	d.fsckRepoDir(ctx, r.Name, opts, report)
+	d.fsckLFSObjects(ctx, r, opts, report)
+}
+
+// fsckOrphanedRepo checks a repository directory that isn't in the
+// database.
+func (d *Backend) fsckOrphanedRepo(ctx context.Context, name string, opts FsckOptions, report func(FsckProblem)) {
+	p := FsckProblem{
+		Repo:    name,
+		Kind:    FsckOrphanedRepo,
+		Details: "repository not found in the database",
+	}
+
+	switch {
+	case opts.Adopt:
+		p.FixErr = d.adoptRepo(ctx, name)
+		p.Fixed = p.FixErr == nil
+	case opts.Prune:
+		p.FixErr = os.RemoveAll(filepath.Join(d.reposPath(), name+".git"))
+		p.Fixed = p.FixErr == nil
+	}
+
+	report(p)
+	if p.Fixed && opts.Adopt {
+		d.fsckRepoDir(ctx, name, opts, report)
+	}
+}
+
+// fsckRepoDir checks the hooks, the last-modified time, and the objects of
+// a repository directory.
+func (d *Backend) fsckRepoDir(ctx context.Context, name string, opts FsckOptions, report func(FsckProblem)) {
+	rp := filepath.Join(d.reposPath(), name+".git")
+	for _, hook := range []string{
+		hooks.PreReceiveHook,
+		hooks.UpdateHook,
+		hooks.PostReceiveHook,
+		hooks.PostUpdateHook,
+	} {
+		_, err := os.Stat(filepath.Join(rp, "hooks", hook))
+		if err == nil {
+			_, err = os.Stat(filepath.Join(rp, "hooks", hook+".d", "soft-serve"))
+		}
+
+		if err != nil {
+			p := FsckProblem{
+				Repo:    name,
+				Kind:    FsckMissingHooks,
+				Details: fmt.Sprintf("%s hook not found", hook),
+			}
+			if opts.fixable() {
+				p.FixErr = hooks.GenerateHooks(ctx, d.cfg, name)
+				p.Fixed = p.FixErr == nil
+			}
+
+			report(p)
+			break
+		}
+	}
+
+	rr, err := git.Open(rp)
+	if err != nil {
+		report(FsckProblem{
+			Repo:    name,
+			Kind:    FsckCorruptRepo,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	// Empty repositories don't have a last-modified time.
+	if t, err := rr.LatestCommitTime(); err == nil {
+		want := t.Format(time.RFC3339)
+		got, _ := readOneline(filepath.Join(rp, "info", "last-modified"))
+		if got != want {
+			p := FsckProblem{
+				Repo:    name,
+				Kind:    FsckStaleLastModified,
+				Details: fmt.Sprintf("last-modified is %q, latest commit is %q", got, want),
+			}
+			if opts.fixable() {
+				p.FixErr = (&repo{path: rp}).writeLastModified(t)
+				p.Fixed = p.FixErr == nil
+			}
+
+			report(p)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := git.NewCommand("fsck", "--no-progress").
+		WithContext(ctx).
+		WithTimeout(-1).
+		RunInDirWithOptions(rp, git.RunInDirOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}); err != nil {
+		details := strings.TrimSpace(stderr.String() + "\n" + stdout.String())
+		if i := strings.IndexByte(details, '\n'); i >= 0 {
+			details = details[:i]
+		}
+		if details == "" {
+			details = err.Error()
+		}
+
+		report(FsckProblem{
+			Repo:    name,
+			Kind:    FsckCorruptRepo,
+			Details: details,
+		})
+	}
+}
+
+// fsckLFSObjects checks that the LFS objects of a repository are stored.
+func (d *Backend) fsckLFSObjects(ctx context.Context, r models.Repo, opts FsckOptions, report func(FsckProblem)) {
+	objs, err := d.store.GetLFSObjects(ctx, d.db, r.ID)
+	if err != nil {
+		d.logger.Error("failed to get lfs objects", "repo", r.Name, "err", err)
+		return
+	}
+
+	root := filepath.Join(d.cfg.DataPath, "lfs", strconv.FormatInt(r.ID, 10))
+	for _, obj := range objs {
+		ptr := lfs.Pointer{Oid: obj.Oid, Size: obj.Size}
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(path.Join("objects", ptr.RelativePath())))); err == nil {
+			continue
+		}
+
+		p := FsckProblem{
+			Repo:    r.Name,
+			Kind:    FsckMissingLFSObject,
+			Details: fmt.Sprintf("lfs object %s not found", obj.Oid),
+		}
+		if opts.Prune {
+			p.FixErr = db.WrapError(d.store.DeleteLFSObjectByOid(ctx, d.db, r.ID, obj.Oid))
+			p.Fixed = p.FixErr == nil
+		}
+
+		report(p)
+	}
+}
+
+// adoptRepo adds an orphaned repository directory to the database.
+func (d *Backend) adoptRepo(ctx context.Context, name string) error {
+	rp := filepath.Join(d.reposPath(), name+".git")
+	desc, _ := readOneline(filepath.Join(rp, "description"))
+	if strings.HasPrefix(desc, "Unnamed repository") {
+		// git init default description
+		desc = ""
+	}
+
+	_, err := os.Stat(filepath.Join(rp, "git-daemon-export-ok"))
+	private := err != nil
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		// Adopted repositories belong to the first admin, like the
+		// repositories of older versions.
+		users, err := d.store.GetAllUsers(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		var owner int64
+		for _, u := range users {
+			if u.Admin && (owner == 0 || u.ID < owner) {
+				owner = u.ID
+			}
+		}
+
+		if owner == 0 {
+			return proto.ErrUserNotFound
+		}
+
+		return d.store.CreateRepo(ctx, tx, name, owner, "", desc, private, false, false)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	return hooks.GenerateHooks(ctx, d.cfg, name)
+}
+
+// reinitRepo initializes an empty repository for a missing repository
+// directory.
+func (d *Backend) reinitRepo(ctx context.Context, r models.Repo) error {
+	rp := filepath.Join(d.reposPath(), r.Name+".git")
+	if _, err := git.Init(rp, true); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(rp, "description"), []byte(r.Description), fs.ModePerm); err != nil {
+		return err
+	}
+
+	if !r.Private {
+		if err := os.WriteFile(filepath.Join(rp, "git-daemon-export-ok"), []byte{}, fs.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	d.cache.Delete(r.Name)
+	return hooks.GenerateHooks(ctx, d.cfg, r.Name)
+}
+
+// pruneRepo deletes a missing repository from the database, and its data
+// stored outside of the repository directory.
+func (d *Backend) pruneRepo(ctx context.Context, r models.Repo) error {
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteRepoByName(ctx, tx, r.Name)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.cache.Delete(r.Name)
+	d.deleteRepoData(r.ID, r.Name)
+	return os.RemoveAll(filepath.Join(d.cfg.DataPath, "lfs", strconv.FormatInt(r.ID, 10)))
+}
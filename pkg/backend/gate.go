@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/gate"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// GateRules returns the gate rules of a repository.
+func (d *Backend) GateRules(ctx context.Context, repo string) ([]models.GateRule, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []models.GateRule
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		rules, err = d.store.GetGateRulesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return rules, nil
+}
+
+// AddGateRule adds a gate rule to a repository. Updates to branches matching
+// the branch pattern must be approved by either the URL or the command.
+// A zero timeout uses the default gate timeout.
+func (d *Backend) AddGateRule(ctx context.Context, repo string, branch string, url string, command string, secret string, timeout time.Duration) (int64, error) {
+	if err := gate.Validate(branch, url, command, timeout); err != nil {
+		return 0, err
+	}
+
+	if timeout == 0 {
+		timeout = gate.DefaultTimeout
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		id, err = d.store.CreateGateRule(ctx, tx, r.ID(), branch, url, command, secret, int64(timeout/time.Second))
+		return err
+	}); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return id, nil
+}
+
+// RemoveGateRule removes a gate rule from a repository.
+func (d *Backend) RemoveGateRule(ctx context.Context, repo string, id int64) error {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	rules, err := d.GateRules(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, rule := range rules {
+		if rule.ID == id {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return proto.ErrGateRuleNotFound
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.DeleteGateRuleForRepoByID(ctx, tx, r.ID(), id)
+		}),
+	)
+}
+
+// checkGates asks the gates matching an updated branch to approve the update.
+// Gates are checked in order and the first denial rejects the update. Gates
+// that fail or time out deny the update too.
+func (d *Backend) checkGates(ctx context.Context, user proto.User, r proto.Repository, arg hooks.HookArg) error {
+	if !strings.HasPrefix(arg.RefName, git.RefsHeads) {
+		return nil
+	}
+
+	var rules []models.GateRule
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		rules, err = d.store.GetGateRulesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		d.logger.Error("error getting repository gate rules", "repo", r.Name(), "err", err)
+		return fmt.Errorf("update to %s denied: %w", arg.RefName, db.WrapError(err))
+	}
+
+	branch := strings.TrimPrefix(arg.RefName, git.RefsHeads)
+	req := gate.Request{
+		Repository: r.Name(),
+		Ref:        arg.RefName,
+		Branch:     branch,
+		Before:     arg.OldSha,
+		After:      arg.NewSha,
+	}
+	if user != nil {
+		req.Pusher = user.Username()
+	}
+
+	for _, rule := range rules {
+		if !gate.Match(rule.Branch, branch) {
+			continue
+		}
+
+		err := gate.Check(ctx, rule, req)
+		var denied *gate.DeniedError
+		switch {
+		case err == nil:
+			continue
+		case errors.As(err, &denied):
+			d.logger.Info("update denied by gate", "repo", r.Name(), "ref", arg.RefName, "gate", rule.ID)
+			return fmt.Errorf("update to %s denied by gate %d: %s", arg.RefName, rule.ID, denied.Message)
+		case errors.Is(err, context.DeadlineExceeded):
+			d.logger.Error("gate timed out", "repo", r.Name(), "ref", arg.RefName, "gate", rule.ID)
+			return fmt.Errorf("update to %s denied: gate %d timed out", arg.RefName, rule.ID)
+		default:
+			d.logger.Error("error checking gate", "repo", r.Name(), "ref", arg.RefName, "gate", rule.ID, "err", err)
+			return fmt.Errorf("update to %s denied: gate %d failed", arg.RefName, rule.ID)
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/soft-serve/git"
@@ -18,8 +21,12 @@ var _ hooks.Hooks = (*Backend)(nil)
 // PostReceive is called by the git post-receive hook.
 //
 // It implements Hooks.
-func (d *Backend) PostReceive(_ context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
+func (d *Backend) PostReceive(ctx context.Context, stdout io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
 	d.logger.Debug("post-receive hook called", "repo", repo, "args", args)
+
+	if d.cfg.CI.Enabled {
+		d.queueCIRuns(ctx, stdout, repo, args)
+	}
 }
 
 // PreReceive is called by the git pre-receive hook.
@@ -29,43 +36,29 @@ func (d *Backend) PreReceive(_ context.Context, _ io.Writer, _ io.Writer, repo s
 	d.logger.Debug("pre-receive hook called", "repo", repo, "args", args)
 }
 
-// Update is called by the git update hook.
+// Update is called by the git update hook. It rejects updates that are
+// denied by the repository gates. Updates are rejected too when the gates
+// can't be checked.
 //
 // It implements Hooks.
-func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo string, arg hooks.HookArg) {
+func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo string, arg hooks.HookArg) error {
 	d.logger.Debug("update hook called", "repo", repo, "arg", arg)
 
-	// Find user
-	var user proto.User
-	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
-		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
-		if err != nil {
-			d.logger.Error("error parsing public key", "err", err)
-			return
-		}
-
-		user, err = d.UserByPublicKey(ctx, pk)
-		if err != nil {
-			d.logger.Error("error finding user from public key", "key", pubkey, "err", err)
-			return
-		}
-	} else if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
-		var err error
-		user, err = d.User(ctx, username)
-		if err != nil {
-			d.logger.Error("error finding user from username", "username", username, "err", err)
-			return
-		}
-	} else {
-		d.logger.Error("error finding user")
-		return
+	user, err := d.hookUser(ctx)
+	if err != nil {
+		d.logger.Error("error finding user", "repo", repo, "err", err)
+		return fmt.Errorf("update to %s denied: %w", arg.RefName, err)
 	}
 
 	// Get repo
 	r, err := d.Repository(ctx, repo)
 	if err != nil {
 		d.logger.Error("error finding repository", "repo", repo, "err", err)
-		return
+		return fmt.Errorf("update to %s denied: %w", arg.RefName, err)
+	}
+
+	if err := d.checkGates(ctx, user, r, arg); err != nil {
+		return err
 	}
 
 	// TODO: run this async
@@ -74,16 +67,53 @@ func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo str
 		wh, err := webhook.NewBranchTagEvent(ctx, user, r, arg.RefName, arg.OldSha, arg.NewSha)
 		if err != nil {
 			d.logger.Error("error creating branch_tag webhook", "err", err)
-		} else if err := webhook.SendEvent(ctx, wh); err != nil {
+		} else if err := d.SendEvent(ctx, wh); err != nil {
 			d.logger.Error("error sending branch_tag webhook", "err", err)
 		}
 	}
+	// Tag pushes are sent as tag events in addition to push events.
+	if strings.HasPrefix(arg.RefName, git.RefsTags) {
+		wh, err := webhook.NewTagEvent(ctx, user, r, arg.RefName, arg.OldSha, arg.NewSha)
+		if err != nil {
+			d.logger.Error("error creating tag webhook", "err", err)
+		} else if err := d.SendEvent(ctx, wh); err != nil {
+			d.logger.Error("error sending tag webhook", "err", err)
+		}
+	}
+
 	wh, err := webhook.NewPushEvent(ctx, user, r, arg.RefName, arg.OldSha, arg.NewSha)
 	if err != nil {
 		d.logger.Error("error creating push webhook", "err", err)
-	} else if err := webhook.SendEvent(ctx, wh); err != nil {
+	} else if err := d.SendEvent(ctx, wh); err != nil {
 		d.logger.Error("error sending push webhook", "err", err)
 	}
+
+	return nil
+}
+
+// hookUser returns the user pushing to a repository from the hook
+// environment. It returns nil for anonymous pushes, e.g. over the Git daemon
+// or over SSH with a key that doesn't belong to a user.
+func (d *Backend) hookUser(ctx context.Context) (proto.User, error) {
+	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
+		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key: %w", err)
+		}
+
+		user, err := d.UserByPublicKey(ctx, pk)
+		if errors.Is(err, proto.ErrUserNotFound) {
+			return nil, nil
+		}
+
+		return user, err
+	}
+
+	if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
+		return d.User(ctx, username)
+	}
+
+	return nil, nil
 }
 
 // PostUpdate is called by the git post-update hook.
@@ -104,6 +134,16 @@ func (d *Backend) PostUpdate(ctx context.Context, _ io.Writer, _ io.Writer, repo
 		}
 	}()
 
+	// Rebuild the search index.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := d.IndexRepository(ctx, repo); err != nil {
+			d.logger.Error("error indexing repository", "repo", repo, "err", err)
+			return
+		}
+	}()
+
 	wg.Wait()
 }
 
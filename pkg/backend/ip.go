@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// AllowAddr returns whether the given remote address is allowed to access
+// the server and, if repo is not empty, the given repository.
+//
+// Global rules from the server configuration are evaluated first, then the
+// repository rules. Rules that fail to load deny access.
+func (d *Backend) AllowAddr(ctx context.Context, repo string, addr string) bool {
+	ip := access.AddrIP(addr)
+	rules, err := access.ParseIPRules(d.cfg.IP.Allow, d.cfg.IP.Deny)
+	if err != nil {
+		d.logger.Error("error parsing ip rules", "err", err)
+		return false
+	}
+
+	if !rules.Allowed(ip) {
+		d.logger.Debug("address denied by global ip rules", "addr", addr)
+		return false
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	if repo == "" {
+		return true
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		// Repository doesn't exist yet, only global rules apply.
+		return errors.Is(err, proto.ErrRepoNotFound)
+	}
+
+	var m []models.IPRule
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetIPRulesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		d.logger.Error("error getting repository ip rules", "repo", repo, "err", err)
+		return false
+	}
+
+	var allow, deny []string
+	for _, rule := range m {
+		if rule.Allow {
+			allow = append(allow, rule.CIDR)
+		} else {
+			deny = append(deny, rule.CIDR)
+		}
+	}
+
+	rules, err = access.ParseIPRules(allow, deny)
+	if err != nil {
+		d.logger.Error("error parsing repository ip rules", "repo", repo, "err", err)
+		return false
+	}
+
+	if !rules.Allowed(ip) {
+		d.logger.Debug("address denied by repository ip rules", "repo", repo, "addr", addr)
+		return false
+	}
+
+	return true
+}
+
+// IPRules returns the IP access rules of a repository.
+func (d *Backend) IPRules(ctx context.Context, repo string) ([]models.IPRule, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []models.IPRule
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		rules, err = d.store.GetIPRulesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return rules, nil
+}
+
+// AddIPRule adds an allow or deny IP access rule to a repository.
+func (d *Backend) AddIPRule(ctx context.Context, repo string, cidr string, allow bool) (int64, error) {
+	n, err := access.ParseCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			id, err = d.store.CreateIPRule(ctx, tx, r.ID(), n.String(), allow)
+			return err
+		}),
+	); err != nil {
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return 0, proto.ErrIPRuleExist
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// RemoveIPRule removes an IP access rule from a repository.
+func (d *Backend) RemoveIPRule(ctx context.Context, repo string, id int64) error {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	rules, err := d.IPRules(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, rule := range rules {
+		if rule.ID == id {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return proto.ErrIPRuleNotFound
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.DeleteIPRuleForRepoByID(ctx, tx, r.ID(), id)
+		}),
+	)
+}
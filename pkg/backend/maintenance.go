@@ -0,0 +1,229 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	maintenanceCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "maintenance",
+		Name:      "runs_total",
+		Help:      "The total number of repository maintenance runs",
+	}, []string{"status"})
+
+	maintenanceDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "soft_serve",
+		Subsystem: "maintenance",
+		Name:      "duration_seconds",
+		Help:      "The duration of repository maintenance runs",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8),
+	})
+
+	maintenanceSavedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "maintenance",
+		Name:      "saved_bytes_total",
+		Help:      "The total number of bytes saved by repository maintenance",
+	})
+)
+
+// RepoObjects are the object storage statistics of a repository.
+type RepoObjects struct {
+	// Loose is the number of loose objects.
+	Loose int64
+	// Packs is the number of packs.
+	Packs int64
+	// Size is the size of the loose objects, packs, and garbage in bytes.
+	Size int64
+	// CommitGraph is whether the repository has a commit-graph.
+	CommitGraph bool
+	// Bitmap is whether the repository has a pack bitmap index.
+	Bitmap bool
+}
+
+// Empty returns whether the repository doesn't have any objects.
+func (o RepoObjects) Empty() bool {
+	return o.Loose == 0 && o.Packs == 0
+}
+
+// MaintenanceResult is the result of a repository maintenance run.
+type MaintenanceResult struct {
+	// Repo is the repository name.
+	Repo string
+	// Ran is whether maintenance ran. Scheduled maintenance is skipped for
+	// repositories that don't need it.
+	Ran bool
+	// Duration is the duration of the maintenance run.
+	Duration time.Duration
+	// Before are the object statistics before maintenance.
+	Before RepoObjects
+	// After are the object statistics after maintenance.
+	After RepoObjects
+}
+
+// SavedBytes returns the number of bytes saved by maintenance.
+func (r MaintenanceResult) SavedBytes() int64 {
+	return r.Before.Size - r.After.Size
+}
+
+// RepositoryObjects returns the object storage statistics of a repository.
+func (d *Backend) RepositoryObjects(ctx context.Context, repo string) (RepoObjects, error) {
+	repo = utils.SanitizeRepo(repo)
+	if _, err := d.Repository(ctx, repo); err != nil {
+		return RepoObjects{}, err
+	}
+
+	return repoObjects(ctx, filepath.Join(d.reposPath(), repo+".git"))
+}
+
+// MaintainRepository repacks a repository, removing unreachable objects
+// older than two weeks, and writes its commit-graph and bitmap index. Unless
+// force is true, repositories are only maintained when they have more loose
+// objects or packs than the configured limits, or no commit-graph.
+func (d *Backend) MaintainRepository(ctx context.Context, repo string, force bool) (MaintenanceResult, error) {
+	repo = utils.SanitizeRepo(repo)
+	res := MaintenanceResult{Repo: repo}
+	if _, err := d.Repository(ctx, repo); err != nil {
+		return res, err
+	}
+
+	if _, running := d.maintaining.LoadOrStore(repo, struct{}{}); running {
+		return res, proto.ErrMaintenanceRunning
+	}
+
+	defer d.maintaining.Delete(repo)
+
+	rp := filepath.Join(d.reposPath(), repo+".git")
+	before, err := repoObjects(ctx, rp)
+	if err != nil {
+		return res, err
+	}
+
+	res.Before = before
+	res.After = before
+	if before.Empty() || (!force && !d.needsMaintenance(before)) {
+		return res, nil
+	}
+
+	d.logger.Debug("maintaining repository", "repo", repo, "loose", before.Loose, "packs", before.Packs)
+	start := time.Now()
+	for _, args := range [][]string{
+		// Bare repositories write bitmaps by default, make sure they do.
+		{"-c", "repack.writeBitmaps=true", "gc", "--quiet", "--prune=2.weeks.ago"},
+		{"commit-graph", "write", "--reachable", "--changed-paths"},
+	} {
+		if err := runGit(ctx, rp, args...); err != nil {
+			maintenanceCounter.WithLabelValues("failure").Inc()
+			return res, err
+		}
+	}
+
+	res.Ran = true
+	res.Duration = time.Since(start)
+	res.After, err = repoObjects(ctx, rp)
+	if err != nil {
+		return res, err
+	}
+
+	maintenanceCounter.WithLabelValues("success").Inc()
+	maintenanceDuration.Observe(res.Duration.Seconds())
+	if saved := res.SavedBytes(); saved > 0 {
+		maintenanceSavedBytes.Add(float64(saved))
+	}
+
+	d.logger.Info("maintained repository", "repo", repo, "duration", res.Duration, "saved", res.SavedBytes())
+	return res, nil
+}
+
+// needsMaintenance returns whether a repository needs maintenance.
+func (d *Backend) needsMaintenance(o RepoObjects) bool {
+	return o.Loose > int64(d.cfg.Maintenance.LooseObjects) ||
+		o.Packs > int64(d.cfg.Maintenance.Packs) ||
+		!o.CommitGraph
+}
+
+// repoObjects returns the object storage statistics of the repository at
+// path.
+func repoObjects(ctx context.Context, path string) (RepoObjects, error) {
+	var stdout, stderr bytes.Buffer
+	if err := git.NewCommand("count-objects", "-v").
+		WithContext(ctx).
+		WithTimeout(-1).
+		RunInDirWithOptions(path, git.RunInDirOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}); err != nil {
+		return RepoObjects{}, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var o RepoObjects
+	s := bufio.NewScanner(&stdout)
+	for s.Scan() {
+		k, v, ok := strings.Cut(s.Text(), ": ")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch k {
+		case "count":
+			o.Loose = n
+		case "packs":
+			o.Packs = n
+		case "size", "size-pack", "size-garbage":
+			// Sizes are in KiB.
+			o.Size += n * 1024
+		}
+	}
+
+	objects := filepath.Join(path, "objects")
+	if _, err := os.Stat(filepath.Join(objects, "info", "commit-graph")); err == nil {
+		o.CommitGraph = true
+	} else if _, err := os.Stat(filepath.Join(objects, "info", "commit-graphs")); err == nil {
+		o.CommitGraph = true
+	}
+
+	bitmaps, _ := filepath.Glob(filepath.Join(objects, "pack", "*.bitmap"))
+	o.Bitmap = len(bitmaps) > 0
+
+	return o, s.Err()
+}
+
+// runGit runs a git command in the repository at path.
+func runGit(ctx context.Context, path string, args ...string) error {
+	return runGitWithOptions(ctx, path, git.RunInDirOptions{}, args...)
+}
+
+// runGitWithOptions runs a git command in path with the given stdin and
+// stdout. Errors include the command's standard error.
+func runGitWithOptions(ctx context.Context, path string, opts git.RunInDirOptions, args ...string) error {
+	var stderr bytes.Buffer
+	opts.Stderr = &stderr
+	if err := git.NewCommand(args...).
+		WithContext(ctx).
+		WithTimeout(-1).
+		RunInDirWithOptions(path, opts); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
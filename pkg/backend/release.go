@@ -0,0 +1,280 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAssetName is returned when a release asset name is invalid.
+var ErrInvalidAssetName = errors.New("invalid asset name")
+
+// releaseStorage returns the storage of the release assets of a repository.
+func (d *Backend) releaseStorage(repoID int64) storage.Storage {
+	return storage.NewLocalStorage(filepath.Join(d.cfg.DataPath, "releases", strconv.FormatInt(repoID, 10)))
+}
+
+// releaseAssetPath returns the storage path of a release asset.
+func releaseAssetPath(releaseID int64, name string) string {
+	return path.Join(strconv.FormatInt(releaseID, 10), name)
+}
+
+// validateAssetName makes sure an asset name is a plain file name.
+func validateAssetName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return ErrInvalidAssetName
+	}
+
+	return nil
+}
+
+// Releases returns the releases of a repository, newest first.
+func (d *Backend) Releases(ctx context.Context, repo string) ([]models.Release, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []models.Release
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		releases, err = d.store.GetReleasesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return releases, nil
+}
+
+// Release returns the release of a repository tag and its assets.
+func (d *Backend) Release(ctx context.Context, repo string, tag string) (models.Release, []models.ReleaseAsset, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.Release{}, nil, err
+	}
+
+	var release models.Release
+	var assets []models.ReleaseAsset
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		release, err = d.store.GetReleaseByRepoIDAndTag(ctx, tx, r.ID(), tag)
+		if err != nil {
+			return err
+		}
+
+		assets, err = d.store.GetReleaseAssetsByReleaseID(ctx, tx, release.ID)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Release{}, nil, proto.ErrReleaseNotFound
+		}
+
+		return models.Release{}, nil, err
+	}
+
+	return release, assets, nil
+}
+
+// CreateRelease creates a release for an existing repository tag.
+func (d *Backend) CreateRelease(ctx context.Context, repo string, tag string, title string, notes string) (models.Release, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	rr, err := r.Open()
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	if !rr.HasTag(tag) {
+		return models.Release{}, git.ErrReferenceNotExist
+	}
+
+	if title == "" {
+		title = tag
+	}
+
+	var userID int64
+	user := proto.UserFromContext(ctx)
+	if user != nil {
+		userID = user.ID()
+	}
+
+	if err := db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			_, err := d.store.CreateRelease(ctx, tx, r.ID(), userID, tag, title, notes)
+			return err
+		}),
+	); err != nil {
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return models.Release{}, proto.ErrReleaseExist
+		}
+
+		return models.Release{}, err
+	}
+
+	release, _, err := d.Release(ctx, repo, tag)
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	wh, err := webhook.NewReleaseEvent(ctx, user, r, release, nil, webhook.ReleaseEventActionPublished)
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	return release, d.SendEvent(ctx, wh)
+}
+
+// DeleteRelease deletes the release of a repository tag and its assets. The
+// tag itself is kept.
+func (d *Backend) DeleteRelease(ctx context.Context, repo string, tag string) error {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	release, assets, err := d.Release(ctx, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	// We create the webhook event before deleting the release so the payload
+	// includes the deleted assets.
+	wh, err := webhook.NewReleaseEvent(ctx, proto.UserFromContext(ctx), r, release, assets, webhook.ReleaseEventActionDeleted)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteReleaseByID(ctx, tx, release.ID)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	strg := d.releaseStorage(r.ID())
+	for _, a := range assets {
+		d.logger.Debug("deleting release asset", "repo", repo, "tag", tag, "asset", a.Name)
+		if err := strg.Delete(releaseAssetPath(release.ID, a.Name)); err != nil {
+			d.logger.Error("failed to delete release asset", "repo", repo, "tag", tag, "asset", a.Name, "err", err)
+		}
+	}
+
+	return d.SendEvent(ctx, wh)
+}
+
+// UploadReleaseAsset uploads an asset to the release of a repository tag.
+// An existing asset with the same name is replaced.
+func (d *Backend) UploadReleaseAsset(ctx context.Context, repo string, tag string, name string, r io.Reader) (models.ReleaseAsset, error) {
+	if err := validateAssetName(name); err != nil {
+		return models.ReleaseAsset{}, err
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	rr, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.ReleaseAsset{}, err
+	}
+
+	release, _, err := d.Release(ctx, repo, tag)
+	if err != nil {
+		return models.ReleaseAsset{}, err
+	}
+
+	// Write the asset to a temporary location first so that a failed upload
+	// doesn't replace an existing asset.
+	strg := d.releaseStorage(rr.ID())
+	tmp := path.Join("tmp", uuid.NewString())
+	size, err := strg.Put(tmp, r)
+	if err != nil {
+		strg.Delete(tmp) // nolint: errcheck
+		return models.ReleaseAsset{}, err
+	}
+
+	if err := strg.Rename(tmp, releaseAssetPath(release.ID, name)); err != nil {
+		strg.Delete(tmp) // nolint: errcheck
+		return models.ReleaseAsset{}, err
+	}
+
+	var asset models.ReleaseAsset
+	var assets []models.ReleaseAsset
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		a, err := d.store.GetReleaseAssetByName(ctx, tx, release.ID, name)
+		switch {
+		case err == nil:
+			err = d.store.SetReleaseAssetSizeByID(ctx, tx, a.ID, size)
+		case errors.Is(db.WrapError(err), db.ErrRecordNotFound):
+			err = d.store.CreateReleaseAsset(ctx, tx, release.ID, name, size)
+		}
+		if err != nil {
+			return err
+		}
+
+		asset, err = d.store.GetReleaseAssetByName(ctx, tx, release.ID, name)
+		if err != nil {
+			return err
+		}
+
+		assets, err = d.store.GetReleaseAssetsByReleaseID(ctx, tx, release.ID)
+		return err
+	}); err != nil {
+		return models.ReleaseAsset{}, db.WrapError(err)
+	}
+
+	wh, err := webhook.NewReleaseEvent(ctx, proto.UserFromContext(ctx), rr, release, assets, webhook.ReleaseEventActionUpdated)
+	if err != nil {
+		return models.ReleaseAsset{}, err
+	}
+
+	return asset, d.SendEvent(ctx, wh)
+}
+
+// ReleaseAsset returns a release asset and its content. The caller must close
+// the returned object.
+func (d *Backend) ReleaseAsset(ctx context.Context, repo string, tag string, name string) (models.ReleaseAsset, storage.Object, error) {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return models.ReleaseAsset{}, nil, err
+	}
+
+	release, assets, err := d.Release(ctx, repo, tag)
+	if err != nil {
+		return models.ReleaseAsset{}, nil, err
+	}
+
+	for _, a := range assets {
+		if a.Name != name {
+			continue
+		}
+
+		obj, err := d.releaseStorage(r.ID()).Open(releaseAssetPath(release.ID, a.Name))
+		if err != nil {
+			return models.ReleaseAsset{}, nil, err
+		}
+
+		return a, obj, nil
+	}
+
+	return models.ReleaseAsset{}, nil, proto.ErrReleaseAssetNotFound
+}
@@ -10,9 +10,11 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/hooks"
@@ -28,7 +30,13 @@ func (d *Backend) reposPath() string {
 	return filepath.Join(d.cfg.DataPath, "repos")
 }
 
-// CreateRepository creates a new repository.
+// archivesPath returns the path to the cached repository archives.
+func (d *Backend) archivesPath() string {
+	return filepath.Join(d.cfg.DataPath, "cache", "archives")
+}
+
+// CreateRepository creates a new repository. When opts.Template is set, the
+// repository is initialized from the template repository.
 //
 // It implements backend.Backend.
 func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.User, opts proto.RepositoryOptions) (proto.Repository, error) {
@@ -45,6 +53,19 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 		userID = user.ID()
 	}
 
+	var tmpl proto.Repository
+	if opts.Template != "" {
+		var err error
+		tmpl, err = d.templateRepository(ctx, opts, user)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.TemplateDescription && opts.Description == "" {
+			opts.Description = tmpl.Description()
+		}
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		if err := d.store.CreateRepo(
 			ctx,
@@ -78,7 +99,21 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 			}
 		}
 
-		return hooks.GenerateHooks(ctx, d.cfg, repo)
+		if err := hooks.GenerateHooks(ctx, d.cfg, repo); err != nil {
+			return err
+		}
+
+		if tmpl != nil {
+			if err := d.applyTemplate(ctx, tx, tmpl, name, rp, user, opts); err != nil {
+				d.logger.Error("failed to create repository from template", "repo", name, "template", tmpl.Name(), "err", err)
+				if err := os.RemoveAll(rp); err != nil {
+					d.logger.Error("failed to remove repository", "repo", name, "err", err)
+				}
+				return err
+			}
+		}
+
+		return nil
 	}); err != nil {
 		d.logger.Debug("failed to create repository in database", "err", err)
 		err = db.WrapError(err)
@@ -89,7 +124,19 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 		return nil, err
 	}
 
-	return d.Repository(ctx, name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	wh, err := webhook.NewRepositoryEvent(ctx, user, r, webhook.RepositoryEventActionCreate)
+	if err != nil {
+		d.logger.Error("failed to create repository event", "repo", name, "err", err)
+	} else if err := d.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("failed to send repository event", "repo", name, "err", err)
+	}
+
+	return r, nil
 }
 
 // ImportRepository imports a repository from remote.
@@ -212,7 +259,8 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 	return <-repoc, <-done
 }
 
-// DeleteRepository deletes a repository.
+// DeleteRepository deletes a repository. Unless the trash is disabled, the
+// repository is moved to the trash and can be restored until it's purged.
 //
 // It implements backend.Backend.
 func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
@@ -250,6 +298,10 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 			return db.WrapError(dberr)
 		}
 
+		if d.cfg.Trash.Retention > 0 && ferr == nil {
+			return d.trashRepo(ctx, tx, repom, user)
+		}
+
 		repoID := strconv.FormatInt(repom.ID, 10)
 		strg := storage.NewLocalStorage(filepath.Join(d.cfg.DataPath, "lfs", repoID))
 		objs, err := d.store.GetLFSObjectsByName(ctx, tx, name)
@@ -273,6 +325,8 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 			return db.WrapError(err)
 		}
 
+		d.deleteRepoData(repom.ID, name)
+
 		return os.RemoveAll(rp)
 	}); err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -282,7 +336,27 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 		return db.WrapError(err)
 	}
 
-	return webhook.SendEvent(ctx, wh)
+	return d.SendEvent(ctx, wh)
+}
+
+// deleteRepoData deletes the data stored outside of a repository directory,
+// except LFS objects. Errors are logged.
+func (d *Backend) deleteRepoData(repoID int64, name string) {
+	if err := os.RemoveAll(filepath.Join(d.cfg.DataPath, "releases", strconv.FormatInt(repoID, 10))); err != nil {
+		d.logger.Error("failed to delete release assets", "repo", name, "err", err)
+	}
+
+	if err := os.RemoveAll(d.ciLogsPath(repoID)); err != nil {
+		d.logger.Error("failed to delete ci logs", "repo", name, "err", err)
+	}
+
+	if err := os.Remove(d.searchIndexPath(repoID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		d.logger.Error("failed to delete search index", "repo", name, "err", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(d.archivesPath(), name+".git")); err != nil {
+		d.logger.Error("failed to delete cached archives", "repo", name, "err", err)
+	}
 }
 
 // DeleteUserRepositories deletes all user repositories.
@@ -355,6 +429,11 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 			return err
 		}
 
+		// Cached archives are named after the repository, drop them.
+		if err := os.RemoveAll(filepath.Join(d.archivesPath(), oldRepo)); err != nil {
+			d.logger.Error("failed to delete cached archives", "repo", oldName, "err", err)
+		}
+
 		return os.Rename(op, np)
 	}); err != nil {
 		return db.WrapError(err)
@@ -371,7 +450,129 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 		return err
 	}
 
-	return webhook.SendEvent(ctx, wh)
+	return d.SendEvent(ctx, wh)
+}
+
+// TransferRepository transfers the ownership of a repository to another user.
+// The new owner is removed from the repository collaborators since owners
+// have admin access. When keepAccess is true, the previous owner is added as
+// a read-write collaborator.
+//
+// It implements backend.Backend.
+func (d *Backend) TransferRepository(ctx context.Context, name string, newOwner string, keepAccess bool) error {
+	name = utils.SanitizeRepo(name)
+	newOwner = strings.ToLower(newOwner)
+	if err := utils.ValidateUsername(newOwner); err != nil {
+		return err
+	}
+
+	if _, err := d.Repository(ctx, name); err != nil {
+		return err
+	}
+
+	var prev models.User
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		owner, err := d.store.FindUserByUsername(ctx, tx, newOwner)
+		if err != nil {
+			if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+				return proto.ErrUserNotFound
+			}
+			return err
+		}
+
+		r, err := d.store.GetRepoByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		if r.UserID.Valid {
+			prev, err = d.store.GetUserByID(ctx, tx, r.UserID.Int64)
+			if err != nil {
+				return err
+			}
+		}
+
+		if prev.ID == owner.ID {
+			return nil
+		}
+
+		if err := d.store.SetRepoUserIDByName(ctx, tx, name, owner.ID); err != nil {
+			return err
+		}
+
+		if err := d.store.RemoveCollabByUsernameAndRepo(ctx, tx, owner.Username, name); err != nil {
+			return err
+		}
+
+		if keepAccess && prev.ID != 0 {
+			if _, err := d.store.GetCollabByUsernameAndRepo(ctx, tx, prev.Username, name); err == nil {
+				return nil
+			}
+
+			return d.store.AddCollabByUsernameAndRepo(ctx, tx, prev.Username, name, access.ReadWriteAccess)
+		}
+
+		return nil
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if prev.ID == repo.UserID() {
+		return nil
+	}
+
+	wh, err := webhook.NewRepositoryEvent(ctx, proto.UserFromContext(ctx), repo, webhook.RepositoryEventActionTransfer)
+	if err != nil {
+		return err
+	}
+
+	if prev.ID != 0 {
+		wh.PreviousOwner = &webhook.User{
+			ID:       prev.ID,
+			Username: prev.Username,
+		}
+	}
+
+	return d.SendEvent(ctx, wh)
+}
+
+// TransferUserRepositories transfers the ownership of all user repositories
+// to another user.
+//
+// It implements backend.Backend.
+func (d *Backend) TransferUserRepositories(ctx context.Context, username string, newOwner string) error {
+	var repos []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		user, err := d.store.FindUserByUsername(ctx, tx, strings.ToLower(username))
+		if err != nil {
+			return err
+		}
+
+		repos, err = d.store.GetUserRepos(ctx, tx, user.ID)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.ErrUserNotFound
+		}
+		return err
+	}
+
+	for _, r := range repos {
+		if err := d.TransferRepository(ctx, r.Name, newOwner, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Repositories returns a list of repositories per page.
@@ -386,11 +587,22 @@ func (d *Backend) Repositories(ctx context.Context) ([]proto.Repository, error)
 			return err
 		}
 
+		ts, err := d.store.GetRepoTopics(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		topics := make(map[int64][]string)
+		for _, t := range ts {
+			topics[t.RepoID] = append(topics[t.RepoID], t.Topic)
+		}
+
 		for _, m := range ms {
 			r := &repo{
-				name: m.Name,
-				path: filepath.Join(d.reposPath(), m.Name+".git"),
-				repo: m,
+				name:   m.Name,
+				path:   filepath.Join(d.reposPath(), m.Name+".git"),
+				repo:   m,
+				topics: topics[m.ID],
 			}
 
 			// Cache repositories
@@ -412,6 +624,7 @@ func (d *Backend) Repositories(ctx context.Context) ([]proto.Repository, error)
 // It implements backend.Backend.
 func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository, error) {
 	var m models.Repo
+	var topics []string
 	name = utils.SanitizeRepo(name)
 
 	if r, ok := d.cache.Get(name); ok && r != nil {
@@ -429,6 +642,11 @@ func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
 		m, err = d.store.GetRepoByName(ctx, tx, name)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		topics, err = d.store.GetRepoTopicsByRepoID(ctx, tx, m.ID)
 		return db.WrapError(err)
 	}); err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -438,9 +656,10 @@ func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository
 	}
 
 	r := &repo{
-		name: name,
-		path: rp,
-		repo: m,
+		name:   name,
+		path:   rp,
+		repo:   m,
+		topics: topics,
 	}
 
 	// Add to cache
@@ -516,6 +735,70 @@ func (d *Backend) IsHidden(ctx context.Context, name string) (bool, error) {
 	return hidden, nil
 }
 
+// IsArchived returns true if the repository is archived.
+//
+// It implements backend.Backend.
+func (d *Backend) IsArchived(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var archived bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		archived, err = d.store.GetRepoIsArchivedByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, proto.ErrRepoNotFound
+		}
+		return false, err
+	}
+
+	return archived, nil
+}
+
+// IsTemplate returns true if the repository is a template.
+//
+// It implements backend.Backend.
+func (d *Backend) IsTemplate(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var template bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		template, err = d.store.GetRepoIsTemplateByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, proto.ErrRepoNotFound
+		}
+		return false, err
+	}
+
+	return template, nil
+}
+
+// DaemonPush returns true if anonymous pushes over the Git daemon are
+// allowed for the repository.
+//
+// It implements backend.Backend.
+func (d *Backend) DaemonPush(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var daemonPush bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		daemonPush, err = d.store.GetRepoDaemonPushByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, proto.ErrRepoNotFound
+		}
+		return false, err
+	}
+
+	return daemonPush, nil
+}
+
 // ProjectName returns the project name of a repository.
 //
 // It implements backend.Backend.
@@ -547,6 +830,60 @@ func (d *Backend) SetHidden(ctx context.Context, name string, hidden bool) error
 	}))
 }
 
+// SetArchived sets the archived flag of a repository. Archived repositories
+// are read-only, pushes, LFS uploads, and LFS lock creation are rejected.
+//
+// It implements backend.Backend.
+func (d *Backend) SetArchived(ctx context.Context, name string, archived bool) error {
+	name = utils.SanitizeRepo(name)
+	if _, err := d.Repository(ctx, name); err != nil {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsArchivedByName(ctx, tx, name, archived)
+	}))
+}
+
+// SetTemplate sets the template flag of a repository. Template repositories
+// can be used to create new repositories using their content.
+//
+// It implements backend.Backend.
+func (d *Backend) SetTemplate(ctx context.Context, name string, template bool) error {
+	name = utils.SanitizeRepo(name)
+	if _, err := d.Repository(ctx, name); err != nil {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsTemplateByName(ctx, tx, name, template)
+	}))
+}
+
+// SetDaemonPush sets whether anonymous pushes over the Git daemon are
+// allowed for the repository.
+//
+// It implements backend.Backend.
+func (d *Backend) SetDaemonPush(ctx context.Context, name string, daemonPush bool) error {
+	name = utils.SanitizeRepo(name)
+	if _, err := d.Repository(ctx, name); err != nil {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoDaemonPushByName(ctx, tx, name, daemonPush)
+	}))
+}
+
 // SetDescription sets the description of a repository.
 //
 // It implements backend.Backend.
@@ -612,7 +949,7 @@ func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) err
 			return err
 		}
 
-		if err := webhook.SendEvent(ctx, wh); err != nil {
+		if err := d.SendEvent(ctx, wh); err != nil {
 			return err
 		}
 	}
@@ -640,9 +977,10 @@ var _ proto.Repository = (*repo)(nil)
 
 // repo is a Git repository with metadata stored in a SQLite database.
 type repo struct {
-	name string
-	path string
-	repo models.Repo
+	name   string
+	path   string
+	repo   models.Repo
+	topics []string
 }
 
 // ID returns the repository's ID.
@@ -712,6 +1050,27 @@ func (r *repo) IsHidden() bool {
 	return r.repo.Hidden
 }
 
+// IsArchived returns whether the repository is archived.
+//
+// It implements backend.Repository.
+func (r *repo) IsArchived() bool {
+	return r.repo.Archived
+}
+
+// IsTemplate returns whether the repository is a template.
+//
+// It implements backend.Repository.
+func (r *repo) IsTemplate() bool {
+	return r.repo.Template
+}
+
+// Topics returns the repository's topics.
+//
+// It implements backend.Repository.
+func (r *repo) Topics() []string {
+	return r.topics
+}
+
 // CreatedAt returns the repository's creation time.
 func (r *repo) CreatedAt() time.Time {
 	return r.repo.CreatedAt
@@ -0,0 +1,186 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/search"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+const (
+	// DefaultSearchLimit is the default maximum number of search results.
+	DefaultSearchLimit = 100
+
+	// maxSearchLimit is the maximum number of search results.
+	maxSearchLimit = 1000
+)
+
+// SearchOptions are the options of a code search.
+type SearchOptions struct {
+	// Repo limits the search to a repository.
+	Repo string
+	// Regex is whether the query is a regular expression.
+	Regex bool
+	// Limit is the maximum number of results. Zero uses the default limit.
+	Limit int
+	// Addr is the remote address of the searcher. Repositories that don't
+	// allow the address are skipped. Empty skips the IP rules.
+	Addr string
+}
+
+// SearchResult is a code search result.
+type SearchResult struct {
+	// Repo is the repository name.
+	Repo string
+	search.Match
+}
+
+// searchIndexPath returns the path to the search index of a repository.
+func (d *Backend) searchIndexPath(repoID int64) string {
+	return filepath.Join(d.cfg.DataPath, "search", strconv.FormatInt(repoID, 10)+".idx")
+}
+
+// IndexRepository rebuilds the search index of the default branch of a
+// repository when it is out of date.
+func (d *Backend) IndexRepository(ctx context.Context, repo string) error {
+	repo = utils.SanitizeRepo(repo)
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	idx, err := d.indexRepository(ctx, r)
+	if err != nil || idx == nil {
+		return err
+	}
+
+	return idx.Close()
+}
+
+// indexRepository rebuilds the search index of the default branch of a
+// repository when it is out of date and returns it. It returns a nil index
+// for empty repositories. The returned index must be closed.
+func (d *Backend) indexRepository(ctx context.Context, r proto.Repository) (*search.Index, error) {
+	rr, err := r.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	path := d.searchIndexPath(r.ID())
+	head, err := rr.HEAD()
+	if err != nil {
+		// Empty repositories don't have anything to index.
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if idx, err := search.Load(path); err == nil {
+		if idx.Commit == head.ID {
+			return idx, nil
+		}
+
+		idx.Close() // nolint: errcheck
+	}
+
+	d.logger.Debug("indexing repository", "repo", r.Name(), "commit", head.ID)
+	idx, err := search.Build(ctx, rr.Path, head.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := idx.Save(path); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// Search searches the default branch of the repositories the user can read.
+// Repositories are indexed on push, and on first search when they don't have
+// an index yet.
+func (d *Backend) Search(ctx context.Context, user proto.User, query string, opts SearchOptions) ([]SearchResult, error) {
+	q, err := search.NewQuery(query, opts.Regex)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	} else if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	var repos []proto.Repository
+	if opts.Repo != "" {
+		// Check access first to not leak whether the repository exists.
+		name := utils.SanitizeRepo(opts.Repo)
+		if d.AccessLevelForUser(ctx, name, user) < access.ReadOnlyAccess ||
+			(opts.Addr != "" && !d.AllowAddr(ctx, name, opts.Addr)) {
+			return nil, proto.ErrUnauthorized
+		}
+
+		r, err := d.Repository(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		repos = []proto.Repository{r}
+	} else {
+		repos, err = d.Repositories(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Slice(repos, func(i, j int) bool {
+			return repos[i].Name() < repos[j].Name()
+		})
+	}
+
+	results := make([]SearchResult, 0)
+	for _, r := range repos {
+		if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess ||
+			(opts.Addr != "" && !d.AllowAddr(ctx, r.Name(), opts.Addr)) {
+			continue
+		}
+
+		idx, err := search.Load(d.searchIndexPath(r.ID()))
+		if err != nil {
+			idx, err = d.indexRepository(ctx, r)
+			if err != nil {
+				d.logger.Error("error indexing repository", "repo", r.Name(), "err", err)
+				continue
+			}
+		}
+
+		if idx == nil {
+			continue
+		}
+
+		matches, err := idx.Search(q, limit-len(results))
+		idx.Close() // nolint: errcheck
+		if err != nil {
+			d.logger.Error("error searching repository", "repo", r.Name(), "err", err)
+			continue
+		}
+
+		for _, m := range matches {
+			results = append(results, SearchResult{Repo: r.Name(), Match: m})
+		}
+
+		if len(results) >= limit {
+			break
+		}
+	}
+
+	return results, nil
+}
@@ -2,9 +2,12 @@ package backend
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
 )
 
 // AllowKeyless returns whether or not keyless access is allowed.
@@ -27,9 +30,15 @@ func (b *Backend) AllowKeyless(ctx context.Context) bool {
 //
 // It implements backend.Backend.
 func (b *Backend) SetAllowKeyless(ctx context.Context, allow bool) error {
-	return b.db.TransactionContext(ctx, func(tx *db.Tx) error {
+	if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return b.store.SetAllowKeylessAccess(ctx, tx, allow)
-	})
+	}); err != nil {
+		return err
+	}
+
+	b.sendSettingsEvent(ctx, "allow-keyless", strconv.FormatBool(allow))
+
+	return nil
 }
 
 // AnonAccess returns the level of anonymous access.
@@ -52,7 +61,26 @@ func (b *Backend) AnonAccess(ctx context.Context) access.AccessLevel {
 //
 // It implements backend.Backend.
 func (b *Backend) SetAnonAccess(ctx context.Context, level access.AccessLevel) error {
-	return b.db.TransactionContext(ctx, func(tx *db.Tx) error {
+	if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return b.store.SetAnonAccess(ctx, tx, level)
-	})
+	}); err != nil {
+		return err
+	}
+
+	b.sendSettingsEvent(ctx, "anon-access", level.String())
+
+	return nil
+}
+
+// sendSettingsEvent sends a settings change server event to system webhooks.
+func (b *Backend) sendSettingsEvent(ctx context.Context, setting string, value string) {
+	wh, err := webhook.NewSettingsEvent(ctx, proto.UserFromContext(ctx), setting, value)
+	if err != nil {
+		b.logger.Error("failed to create settings event", "setting", setting, "err", err)
+		return
+	}
+
+	if err := b.SendEvent(ctx, wh); err != nil {
+		b.logger.Error("failed to send settings event", "setting", setting, "err", err)
+	}
 }
@@ -0,0 +1,247 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// StarRepository stars a repository for a user. Starring a repository twice
+// is a no-op.
+func (d *Backend) StarRepository(ctx context.Context, name string, user proto.User) error {
+	return d.withRepoUser(ctx, name, user, func(tx *db.Tx, repoID, userID int64) error {
+		if err := d.store.StarRepo(ctx, tx, repoID, userID); err != nil && !errors.Is(err, db.ErrDuplicateKey) {
+			return err
+		}
+		return nil
+	})
+}
+
+// UnstarRepository unstars a repository for a user.
+func (d *Backend) UnstarRepository(ctx context.Context, name string, user proto.User) error {
+	return d.withRepoUser(ctx, name, user, func(tx *db.Tx, repoID, userID int64) error {
+		return d.store.UnstarRepo(ctx, tx, repoID, userID)
+	})
+}
+
+// WatchRepository subscribes a user to the events of a repository. Watching a
+// repository twice is a no-op.
+func (d *Backend) WatchRepository(ctx context.Context, name string, user proto.User) error {
+	return d.withRepoUser(ctx, name, user, func(tx *db.Tx, repoID, userID int64) error {
+		if err := d.store.WatchRepo(ctx, tx, repoID, userID); err != nil && !errors.Is(err, db.ErrDuplicateKey) {
+			return err
+		}
+		return nil
+	})
+}
+
+// UnwatchRepository unsubscribes a user from the events of a repository.
+func (d *Backend) UnwatchRepository(ctx context.Context, name string, user proto.User) error {
+	return d.withRepoUser(ctx, name, user, func(tx *db.Tx, repoID, userID int64) error {
+		return d.store.UnwatchRepo(ctx, tx, repoID, userID)
+	})
+}
+
+// withRepoUser runs fn for an existing repository and a user.
+func (d *Backend) withRepoUser(ctx context.Context, name string, user proto.User, fn func(tx *db.Tx, repoID, userID int64) error) error {
+	if user == nil {
+		return proto.ErrUnauthorized
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return fn(tx, r.ID(), user.ID())
+	}))
+}
+
+// IsStarred returns whether a user starred a repository.
+func (d *Backend) IsStarred(ctx context.Context, name string, user proto.User) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	var starred bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		starred, err = d.store.IsRepoStarred(ctx, tx, r.ID(), user.ID())
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return starred, nil
+}
+
+// IsWatching returns whether a user watches a repository.
+func (d *Backend) IsWatching(ctx context.Context, name string, user proto.User) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	var watching bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		watching, err = d.store.IsRepoWatched(ctx, tx, r.ID(), user.ID())
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return watching, nil
+}
+
+// StarCount returns the number of users that starred a repository.
+func (d *Backend) StarCount(ctx context.Context, name string) (int64, error) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		count, err = d.store.GetRepoStarCount(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return count, nil
+}
+
+// StarredRepositories returns the repositories a user starred, most recently
+// starred first.
+func (d *Backend) StarredRepositories(ctx context.Context, user proto.User) ([]proto.Repository, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	var ms []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.GetStarredReposByUserID(ctx, tx, user.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	repos := make([]proto.Repository, 0, len(ms))
+	for _, m := range ms {
+		r, err := d.Repository(ctx, m.Name)
+		if err != nil {
+			d.logger.Error("failed to get starred repository", "repo", m.Name, "err", err)
+			continue
+		}
+
+		repos = append(repos, r)
+	}
+
+	return repos, nil
+}
+
+// Notifications returns the notifications of a user, newest first. Read
+// notifications are only returned when all is true. Notifications of
+// repositories the user can no longer read are left out.
+func (d *Backend) Notifications(ctx context.Context, user proto.User, all bool) ([]models.Notification, error) {
+	if user == nil {
+		return nil, proto.ErrUnauthorized
+	}
+
+	var ns []models.Notification
+	repos := make(map[int64]string)
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ns, err = d.store.GetNotificationsByUserID(ctx, tx, user.ID(), all)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range ns {
+			if _, ok := repos[n.RepoID]; ok {
+				continue
+			}
+
+			r, err := d.store.GetRepoByID(ctx, tx, n.RepoID)
+			if err != nil {
+				return err
+			}
+
+			repos[n.RepoID] = r.Name
+		}
+
+		return nil
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	readable := ns[:0]
+	for _, n := range ns {
+		// Trashed repositories don't exist anymore.
+		name := repos[n.RepoID]
+		if _, err := d.Repository(ctx, name); err != nil {
+			continue
+		}
+
+		if d.AccessLevelForUser(ctx, name, user) >= access.ReadOnlyAccess {
+			readable = append(readable, n)
+		}
+	}
+
+	return readable, nil
+}
+
+// MarkNotificationsRead marks notifications of a user as read. All the
+// notifications of the user are marked as read when no IDs are given.
+func (d *Backend) MarkNotificationsRead(ctx context.Context, user proto.User, ids ...int64) error {
+	if user == nil {
+		return proto.ErrUnauthorized
+	}
+
+	err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if len(ids) == 0 {
+			return d.store.SetNotificationsReadByUserID(ctx, tx, user.ID())
+		}
+
+		for _, id := range ids {
+			if err := d.store.SetNotificationReadByID(ctx, tx, user.ID(), id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return proto.ErrNotificationNotFound
+	}
+
+	return err
+}
+
+// ClearNotifications deletes the read notifications of a user.
+func (d *Backend) ClearNotifications(ctx context.Context, user proto.User) error {
+	if user == nil {
+		return proto.ErrUnauthorized
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteReadNotificationsByUserID(ctx, tx, user.ID())
+	}))
+}
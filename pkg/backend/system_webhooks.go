@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/google/uuid"
+)
+
+// CreateSystemWebhook creates a system webhook. System webhooks receive events
+// of every repository and server events.
+func (b *Backend) CreateSystemWebhook(ctx context.Context, url string, contentType webhook.ContentType, secret string, events []webhook.Event, active bool, tmpl webhook.Template) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	if err := tmpl.Validate(events...); err != nil {
+		return err
+	}
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		lastID, err := datastore.CreateSystemWebhook(ctx, tx, url, secret, int(contentType), active)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.UpdateSystemWebhookTemplatesByID(ctx, tx, lastID, tmpl.Body, tmpl.Headers); err != nil {
+			return db.WrapError(err)
+		}
+
+		evs := make([]int, len(events))
+		for i, e := range events {
+			evs[i] = int(e)
+		}
+		if err := datastore.CreateWebhookEvents(ctx, tx, lastID, evs); err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	})
+}
+
+// SystemWebhook returns a system webhook.
+func (b *Backend) SystemWebhook(ctx context.Context, id int64) (webhook.Hook, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var wh webhook.Hook
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		h, err := datastore.GetSystemWebhookByID(ctx, tx, id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		events, err := datastore.GetWebhookEventsByWebhookID(ctx, tx, id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		wh = newWebhookHooks([]models.Webhook{h}, map[int64][]models.WebhookEvent{h.ID: events})[0]
+		return nil
+	}); err != nil {
+		return webhook.Hook{}, db.WrapError(err)
+	}
+
+	return wh, nil
+}
+
+// ListSystemWebhooks lists system webhooks.
+func (b *Backend) ListSystemWebhooks(ctx context.Context) ([]webhook.Hook, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var webhooks []models.Webhook
+	webhookEvents := map[int64][]models.WebhookEvent{}
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		webhooks, err = datastore.GetSystemWebhooks(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, h := range webhooks {
+			events, err := datastore.GetWebhookEventsByWebhookID(ctx, tx, h.ID)
+			if err != nil {
+				return err
+			}
+			webhookEvents[h.ID] = events
+		}
+
+		return nil
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return newWebhookHooks(webhooks, webhookEvents), nil
+}
+
+// UpdateSystemWebhook updates a system webhook.
+func (b *Backend) UpdateSystemWebhook(ctx context.Context, id int64, url string, contentType webhook.ContentType, secret string, updatedEvents []webhook.Event, active bool, tmpl webhook.Template) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	if err := tmpl.Validate(updatedEvents...); err != nil {
+		return err
+	}
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := datastore.GetSystemWebhookByID(ctx, tx, id); err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.UpdateSystemWebhookByID(ctx, tx, id, url, secret, int(contentType), active); err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.UpdateSystemWebhookTemplatesByID(ctx, tx, id, tmpl.Body, tmpl.Headers); err != nil {
+			return db.WrapError(err)
+		}
+
+		return updateWebhookEvents(ctx, tx, id, updatedEvents)
+	})
+}
+
+// DeleteSystemWebhook deletes a system webhook.
+func (b *Backend) DeleteSystemWebhook(ctx context.Context, id int64) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := datastore.GetSystemWebhookByID(ctx, tx, id); err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.DeleteWebhookByID(ctx, tx, id); err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	})
+}
+
+// RedeliverSystemWebhookDelivery redelivers a system webhook delivery.
+func (b *Backend) RedeliverSystemWebhookDelivery(ctx context.Context, id int64, delID uuid.UUID) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var delivery models.WebhookDelivery
+	var wh models.Webhook
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		wh, err = datastore.GetSystemWebhookByID(ctx, tx, id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		delivery, err = datastore.GetWebhookDeliveryByID(ctx, tx, id, delID)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	log.Infof("redelivering system webhook delivery %s for webhook %d", delID, id)
+
+	return webhook.RedeliverWebhook(ctx, wh, delivery)
+}
+
+// PingSystemWebhook sends a ping event to a system webhook and returns the
+// recorded delivery.
+func (b *Backend) PingSystemWebhook(ctx context.Context, id int64) (webhook.Delivery, error) {
+	wh, err := b.SystemWebhook(ctx, id)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return b.pingWebhook(ctx, nil, wh)
+}
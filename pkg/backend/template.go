@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// templateRepository returns the template repository a new repository is
+// created from. Templates the user can't read are reported as not found, and
+// copying the template's collaborators or webhooks requires admin access to
+// the template.
+func (d *Backend) templateRepository(ctx context.Context, opts proto.RepositoryOptions, user proto.User) (proto.Repository, error) {
+	tmpl, err := d.Repository(ctx, opts.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	level := d.AccessLevelForUser(ctx, tmpl.Name(), user)
+	if level < access.ReadOnlyAccess {
+		return nil, proto.ErrRepoNotFound
+	}
+
+	if !tmpl.IsTemplate() {
+		return nil, proto.ErrNotTemplate
+	}
+
+	if (opts.TemplateCollabs || opts.TemplateWebhooks) && level < access.AdminAccess {
+		return nil, proto.ErrUnauthorized
+	}
+
+	return tmpl, nil
+}
+
+// applyTemplate initializes a newly created repository from a template. The
+// template's files are added as a single initial commit, and its
+// collaborators and webhooks are copied when requested.
+func (d *Backend) applyTemplate(ctx context.Context, tx *db.Tx, tmpl proto.Repository, name string, rp string, user proto.User, opts proto.RepositoryOptions) error {
+	if opts.TemplateCollabs {
+		collabs, err := d.store.ListCollabsByRepo(ctx, tx, tmpl.Name())
+		if err != nil {
+			return err
+		}
+
+		for _, c := range collabs {
+			u, err := d.store.GetUserByID(ctx, tx, c.UserID)
+			if err != nil {
+				return err
+			}
+
+			if err := d.store.AddCollabByUsernameAndRepo(ctx, tx, u.Username, name, c.AccessLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.TemplateWebhooks {
+		r, err := d.store.GetRepoByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		whs, err := d.store.GetWebhooksByRepoID(ctx, tx, tmpl.ID())
+		if err != nil {
+			return err
+		}
+
+		for _, wh := range whs {
+			id, err := d.store.CreateWebhook(ctx, tx, r.ID, wh.URL, wh.Secret, wh.ContentType, wh.Active)
+			if err != nil {
+				return err
+			}
+
+			events, err := d.store.GetWebhookEventsByWebhookID(ctx, tx, wh.ID)
+			if err != nil {
+				return err
+			}
+
+			evs := make([]int, len(events))
+			for i, e := range events {
+				evs[i] = e.Event
+			}
+
+			if err := d.store.CreateWebhookEvents(ctx, tx, id, evs); err != nil {
+				return err
+			}
+
+			if wh.BodyTemplate != "" || wh.HeadersTemplate != "" {
+				if err := d.store.UpdateWebhookTemplatesByID(ctx, tx, r.ID, id, wh.BodyTemplate, wh.HeadersTemplate); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return d.copyTemplateTree(ctx, tmpl, rp, user)
+}
+
+// copyTemplateTree copies the tree of the template's default branch to the
+// repository at rp and commits it as the initial commit of the same branch.
+// Nothing is copied from empty templates.
+func (d *Backend) copyTemplateTree(ctx context.Context, tmpl proto.Repository, rp string, user proto.User) error {
+	tr, err := tmpl.Open()
+	if err != nil {
+		return err
+	}
+
+	head, err := tr.HEAD()
+	if err != nil {
+		// Empty templates don't have anything to copy.
+		return nil
+	}
+
+	tree := head.ID + "^{tree}"
+	var objects bytes.Buffer
+	if err := runGitWithOptions(ctx, tr.Path, git.RunInDirOptions{Stdout: &objects}, "rev-list", "--objects", tree); err != nil {
+		return err
+	}
+
+	// Stream a pack of the tree objects from the template to the new
+	// repository.
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		err := runGitWithOptions(ctx, tr.Path, git.RunInDirOptions{Stdin: &objects, Stdout: pw}, "pack-objects", "--stdout")
+		pw.CloseWithError(err) // nolint: errcheck
+		errc <- err
+	}()
+
+	if err := runGitWithOptions(ctx, rp, git.RunInDirOptions{Stdin: pr}, "index-pack", "--stdin"); err != nil {
+		pr.CloseWithError(err) // nolint: errcheck
+		<-errc
+		return err
+	}
+
+	if err := <-errc; err != nil {
+		return err
+	}
+
+	var treeID bytes.Buffer
+	if err := runGitWithOptions(ctx, tr.Path, git.RunInDirOptions{Stdout: &treeID}, "rev-parse", tree); err != nil {
+		return err
+	}
+
+	authorName, authorEmail := d.templateAuthor(user)
+	var commitID bytes.Buffer
+	if err := git.NewCommand("commit-tree", strings.TrimSpace(treeID.String()), "-m", "Initial commit").
+		AddEnvs(
+			"GIT_AUTHOR_NAME="+authorName,
+			"GIT_AUTHOR_EMAIL="+authorEmail,
+			"GIT_COMMITTER_NAME="+authorName,
+			"GIT_COMMITTER_EMAIL="+authorEmail,
+		).
+		WithContext(ctx).
+		WithTimeout(-1).
+		RunInDirWithOptions(rp, git.RunInDirOptions{Stdout: &commitID}); err != nil {
+		return fmt.Errorf("git commit-tree: %w", err)
+	}
+
+	if err := runGit(ctx, rp, "update-ref", head.Refspec, strings.TrimSpace(commitID.String())); err != nil {
+		return err
+	}
+
+	return runGit(ctx, rp, "symbolic-ref", "HEAD", head.Refspec)
+}
+
+// templateAuthor returns the name and email of the author of the initial
+// commit of a repository created from a template.
+func (d *Backend) templateAuthor(user proto.User) (string, string) {
+	name, username := d.cfg.Name, "soft-serve"
+	if user != nil {
+		name, username = user.Username(), user.Username()
+	}
+
+	host := "localhost"
+	if u, err := url.Parse(d.cfg.SSH.PublicURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	return name, username + "@" + host
+}
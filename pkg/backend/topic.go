@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// AddRepositoryTopics adds topics to a repository. Topics are lowercased, and
+// topics the repository already has are ignored.
+func (d *Backend) AddRepositoryTopics(ctx context.Context, name string, topics ...string) error {
+	name = utils.SanitizeRepo(name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range topics {
+		topics[i] = strings.ToLower(t)
+		if err := utils.ValidateTopic(topics[i]); err != nil {
+			return err
+		}
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, t := range topics {
+			if err := d.store.AddRepoTopic(ctx, tx, r.ID(), t); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+// RemoveRepositoryTopics removes topics from a repository.
+func (d *Backend) RemoveRepositoryTopics(ctx context.Context, name string, topics ...string) error {
+	name = utils.SanitizeRepo(name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, t := range topics {
+			if err := d.store.RemoveRepoTopic(ctx, tx, r.ID(), strings.ToLower(t)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
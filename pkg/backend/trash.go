@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// trashPath returns the path of the directory trashed repositories are moved
+// to.
+func (d *Backend) trashPath() string {
+	return filepath.Join(d.cfg.DataPath, "trash")
+}
+
+// trashRepoPath returns the path of a trashed repository.
+func (d *Backend) trashRepoPath(repoID int64) string {
+	return filepath.Join(d.trashPath(), strconv.FormatInt(repoID, 10)+".git")
+}
+
+// trashRepoName returns the name a trashed repository is stored under in the
+// database. It isn't a valid repository name, so it can't clash with
+// repositories created after the repository was trashed.
+func trashRepoName(repoID int64) string {
+	return fmt.Sprintf("trash:%d", repoID)
+}
+
+// trashRepo moves a repository to the trash. Its database record, and the
+// records referencing it, are kept under a reserved name so the repository
+// name can be reused.
+func (d *Backend) trashRepo(ctx context.Context, tx *db.Tx, r models.Repo, user proto.User) error {
+	var userID int64
+	if user != nil {
+		userID = user.ID()
+	}
+
+	if _, err := d.store.CreateTrashedRepo(ctx, tx, r.ID, r.Name, userID); err != nil {
+		return err
+	}
+
+	if err := d.store.SetRepoNameByName(ctx, tx, r.Name, trashRepoName(r.ID)); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(d.trashPath(), os.ModePerm); err != nil {
+		return err
+	}
+
+	// Cached archives are named after the repository, drop them.
+	if err := os.RemoveAll(filepath.Join(d.archivesPath(), r.Name+".git")); err != nil {
+		d.logger.Error("failed to delete cached archives", "repo", r.Name, "err", err)
+	}
+
+	d.logger.Info("moved repository to trash", "repo", r.Name, "id", r.ID)
+	return os.Rename(filepath.Join(d.reposPath(), r.Name+".git"), d.trashRepoPath(r.ID))
+}
+
+// TrashedRepositories returns the repositories in the trash, oldest first.
+func (d *Backend) TrashedRepositories(ctx context.Context) ([]models.TrashedRepo, error) {
+	var repos []models.TrashedRepo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		repos, err = d.store.GetTrashedRepos(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return repos, nil
+}
+
+// TrashExpiresAt returns the time a trashed repository is purged at.
+func (d *Backend) TrashExpiresAt(r models.TrashedRepo) time.Time {
+	return r.CreatedAt.AddDate(0, 0, d.cfg.Trash.Retention)
+}
+
+// RestoreRepository restores a trashed repository with its collaborators,
+// webhooks, releases, and LFS objects. The repository gets its original name
+// back, unless name is set.
+func (d *Backend) RestoreRepository(ctx context.Context, id int64, name string) (proto.Repository, error) {
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		t, err := d.store.GetTrashedRepoByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if name == "" {
+			name = t.Name
+		}
+
+		name = utils.SanitizeRepo(name)
+		if err := utils.ValidateRepo(name); err != nil {
+			return err
+		}
+
+		rp := filepath.Join(d.reposPath(), name+".git")
+		if _, err := os.Stat(rp); err == nil {
+			return proto.ErrRepoExist
+		}
+
+		if err := d.store.DeleteTrashedRepoByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		if err := d.store.SetRepoNameByName(ctx, tx, trashRepoName(t.RepoID), name); err != nil {
+			return err
+		}
+
+		// Make sure the repository parent directory exists.
+		if err := os.MkdirAll(filepath.Dir(rp), os.ModePerm); err != nil {
+			return err
+		}
+
+		return os.Rename(d.trashRepoPath(t.RepoID), rp)
+	}); err != nil {
+		err = db.WrapError(err)
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return nil, proto.ErrTrashedRepoNotFound
+		case errors.Is(err, db.ErrDuplicateKey):
+			return nil, proto.ErrRepoExist
+		}
+
+		return nil, err
+	}
+
+	d.cache.Delete(name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("restored repository from trash", "repo", name, "id", r.ID())
+	wh, err := webhook.NewRepositoryEvent(ctx, proto.UserFromContext(ctx), r, webhook.RepositoryEventActionCreate)
+	if err != nil {
+		d.logger.Error("failed to create repository event", "repo", name, "err", err)
+	} else if err := d.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("failed to send repository event", "repo", name, "err", err)
+	}
+
+	return r, nil
+}
+
+// PurgeRepository permanently deletes a trashed repository, including its
+// LFS objects.
+func (d *Backend) PurgeRepository(ctx context.Context, id int64) error {
+	var t models.TrashedRepo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		t, err = d.store.GetTrashedRepoByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Deleting the repository removes the trash record.
+		return d.store.DeleteRepoByName(ctx, tx, trashRepoName(t.RepoID))
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.ErrTrashedRepoNotFound
+		}
+
+		return err
+	}
+
+	d.logger.Info("purged repository from trash", "repo", t.Name, "id", t.RepoID)
+	return d.purgeRepoData(t.RepoID)
+}
+
+// PurgeExpiredRepositories purges the trashed repositories older than the
+// retention period, and the trash directories left behind by repositories
+// deleted with their owner. It returns the number of purged repositories.
+func (d *Backend) PurgeExpiredRepositories(ctx context.Context) (int, error) {
+	repos, err := d.TrashedRepositories(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	now := time.Now()
+	trashed := map[int64]bool{}
+	for _, r := range repos {
+		trashed[r.RepoID] = true
+		if now.Before(d.TrashExpiresAt(r)) {
+			continue
+		}
+
+		if err := d.PurgeRepository(ctx, r.ID); err != nil {
+			if errors.Is(err, proto.ErrTrashedRepoNotFound) {
+				// Purged or restored concurrently.
+				continue
+			}
+			return n, err
+		}
+
+		n++
+	}
+
+	entries, err := os.ReadDir(d.trashPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return n, nil
+		}
+		return n, err
+	}
+
+	for _, e := range entries {
+		repoID, err := strconv.ParseInt(strings.TrimSuffix(e.Name(), ".git"), 10, 64)
+		if err != nil || trashed[repoID] {
+			continue
+		}
+
+		// The repository is being trashed if its record still exists.
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			_, err := d.store.GetRepoByID(ctx, tx, repoID)
+			return err
+		}); !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			continue
+		}
+
+		d.logger.Info("purging orphaned trash directory", "id", repoID)
+		if err := d.purgeRepoData(repoID); err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	return n, nil
+}
+
+// purgeRepoData removes the trashed directory of a repository and the data
+// stored outside of it. Cached archives were removed when the repository was
+// trashed.
+func (d *Backend) purgeRepoData(repoID int64) error {
+	d.deleteRepoData(repoID, trashRepoName(repoID))
+
+	if err := os.RemoveAll(filepath.Join(d.cfg.DataPath, "lfs", strconv.FormatInt(repoID, 10))); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(d.trashRepoPath(repoID))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -290,7 +291,14 @@ func (d *Backend) CreateUser(ctx context.Context, username string, opts proto.Us
 		return nil, db.WrapError(err)
 	}
 
-	return d.User(ctx, username)
+	user, err := d.User(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	d.sendUserEvent(ctx, user, webhook.UserEventActionCreate)
+
+	return user, nil
 }
 
 // DeleteUser deletes a user.
@@ -302,13 +310,37 @@ func (d *Backend) DeleteUser(ctx context.Context, username string) error {
 		return err
 	}
 
-	return d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+	user, err := d.User(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		if err := d.store.DeleteUserByUsername(ctx, tx, username); err != nil {
 			return db.WrapError(err)
 		}
 
 		return d.DeleteUserRepositories(ctx, username)
-	})
+	}); err != nil {
+		return err
+	}
+
+	d.sendUserEvent(ctx, user, webhook.UserEventActionDelete)
+
+	return nil
+}
+
+// sendUserEvent sends a user server event to system webhooks.
+func (d *Backend) sendUserEvent(ctx context.Context, user proto.User, action webhook.UserEventAction) {
+	wh, err := webhook.NewUserEvent(ctx, proto.UserFromContext(ctx), user, action)
+	if err != nil {
+		d.logger.Error("failed to create user event", "user", user.Username(), "err", err)
+		return
+	}
+
+	if err := d.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("failed to send user event", "user", user.Username(), "err", err)
+	}
 }
 
 // RemovePublicKey removes a public key from a user.
@@ -2,9 +2,10 @@ package backend
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 
 	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
@@ -14,16 +15,24 @@ import (
 )
 
 // CreateWebhook creates a webhook for a repository.
-func (b *Backend) CreateWebhook(ctx context.Context, repo proto.Repository, url string, contentType webhook.ContentType, secret string, events []webhook.Event, active bool) error {
+func (b *Backend) CreateWebhook(ctx context.Context, repo proto.Repository, url string, contentType webhook.ContentType, secret string, events []webhook.Event, active bool, tmpl webhook.Template) error {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
+	if err := tmpl.Validate(events...); err != nil {
+		return err
+	}
+
 	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 		lastID, err := datastore.CreateWebhook(ctx, tx, repo.ID(), url, secret, int(contentType), active)
 		if err != nil {
 			return db.WrapError(err)
 		}
 
+		if err := datastore.UpdateWebhookTemplatesByID(ctx, tx, repo.ID(), lastID, tmpl.Body, tmpl.Headers); err != nil {
+			return db.WrapError(err)
+		}
+
 		evs := make([]int, len(events))
 		for i, e := range events {
 			evs[i] = int(e)
@@ -96,78 +105,104 @@ func (b *Backend) ListWebhooks(ctx context.Context, repo proto.Repository) ([]we
 		return nil, db.WrapError(err)
 	}
 
-	hooks := make([]webhook.Hook, len(webhooks))
-	for i, h := range webhooks {
-		events := make([]webhook.Event, len(webhookEvents[h.ID]))
-		for i, e := range webhookEvents[h.ID] {
-			events[i] = webhook.Event(e.Event)
-		}
-
-		hooks[i] = webhook.Hook{
-			Webhook:     h,
-			ContentType: webhook.ContentType(h.ContentType),
-			Events:      events,
-		}
-	}
-
-	return hooks, nil
+	return newWebhookHooks(webhooks, webhookEvents), nil
 }
 
 // UpdateWebhook updates a webhook.
-func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id int64, url string, contentType webhook.ContentType, secret string, updatedEvents []webhook.Event, active bool) error {
+func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id int64, url string, contentType webhook.ContentType, secret string, updatedEvents []webhook.Event, active bool, tmpl webhook.Template) error {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
+	if err := tmpl.Validate(updatedEvents...); err != nil {
+		return err
+	}
+
 	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := datastore.GetWebhookByID(ctx, tx, repo.ID(), id); err != nil {
+			return db.WrapError(err)
+		}
+
 		if err := datastore.UpdateWebhookByID(ctx, tx, repo.ID(), id, url, secret, int(contentType), active); err != nil {
 			return db.WrapError(err)
 		}
 
-		currentEvents, err := datastore.GetWebhookEventsByWebhookID(ctx, tx, id)
-		if err != nil {
+		if err := datastore.UpdateWebhookTemplatesByID(ctx, tx, repo.ID(), id, tmpl.Body, tmpl.Headers); err != nil {
 			return db.WrapError(err)
 		}
 
-		// Delete events that are no longer in the list.
-		toBeDeleted := make([]int64, 0)
-		for _, e := range currentEvents {
-			found := false
-			for _, ne := range updatedEvents {
-				if int(ne) == e.Event {
-					found = true
-					break
-				}
-			}
-			if !found {
-				toBeDeleted = append(toBeDeleted, e.ID)
+		return updateWebhookEvents(ctx, tx, id, updatedEvents)
+	})
+}
+
+// updateWebhookEvents replaces the events of a webhook with the given events.
+func updateWebhookEvents(ctx context.Context, tx *db.Tx, id int64, updatedEvents []webhook.Event) error {
+	datastore := store.FromContext(ctx)
+
+	currentEvents, err := datastore.GetWebhookEventsByWebhookID(ctx, tx, id)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	// Delete events that are no longer in the list.
+	toBeDeleted := make([]int64, 0)
+	for _, e := range currentEvents {
+		found := false
+		for _, ne := range updatedEvents {
+			if int(ne) == e.Event {
+				found = true
+				break
 			}
 		}
+		if !found {
+			toBeDeleted = append(toBeDeleted, e.ID)
+		}
+	}
 
+	if len(toBeDeleted) > 0 {
 		if err := datastore.DeleteWebhookEventsByID(ctx, tx, toBeDeleted); err != nil {
 			return db.WrapError(err)
 		}
+	}
 
-		// Prune events that are already in the list.
-		newEvents := make([]int, 0)
-		for _, e := range updatedEvents {
-			found := false
-			for _, ne := range currentEvents {
-				if int(e) == ne.Event {
-					found = true
-					break
-				}
-			}
-			if !found {
-				newEvents = append(newEvents, int(e))
+	// Prune events that are already in the list.
+	newEvents := make([]int, 0)
+	for _, e := range updatedEvents {
+		found := false
+		for _, ne := range currentEvents {
+			if int(e) == ne.Event {
+				found = true
+				break
 			}
 		}
+		if !found {
+			newEvents = append(newEvents, int(e))
+		}
+	}
 
-		if err := datastore.CreateWebhookEvents(ctx, tx, id, newEvents); err != nil {
-			return db.WrapError(err)
+	if err := datastore.CreateWebhookEvents(ctx, tx, id, newEvents); err != nil {
+		return db.WrapError(err)
+	}
+
+	return nil
+}
+
+// newWebhookHooks returns the hooks of the given webhooks and their events.
+func newWebhookHooks(webhooks []models.Webhook, webhookEvents map[int64][]models.WebhookEvent) []webhook.Hook {
+	hooks := make([]webhook.Hook, len(webhooks))
+	for i, h := range webhooks {
+		events := make([]webhook.Event, len(webhookEvents[h.ID]))
+		for i, e := range webhookEvents[h.ID] {
+			events[i] = webhook.Event(e.Event)
 		}
 
-		return nil
-	})
+		hooks[i] = webhook.Hook{
+			Webhook:     h,
+			ContentType: webhook.ContentType(h.ContentType),
+			Events:      events,
+		}
+	}
+
+	return hooks
 }
 
 // DeleteWebhook deletes a webhook for a repository.
@@ -242,15 +277,34 @@ func (b *Backend) RedeliverWebhookDelivery(ctx context.Context, repo proto.Repos
 		return db.WrapError(err)
 	}
 
-	log.Infof("redelivering webhook delivery %s for webhook %d\n\n%s\n\n", delID, id, delivery.RequestBody)
+	log.Infof("redelivering webhook delivery %s for webhook %d", delID, id)
 
-	var payload json.RawMessage
-	if err := json.Unmarshal([]byte(delivery.RequestBody), &payload); err != nil {
-		log.Errorf("error unmarshaling webhook payload: %v", err)
-		return err
+	return webhook.RedeliverWebhook(ctx, wh, delivery)
+}
+
+// PingWebhook sends a ping event to a repository webhook and returns the
+// recorded delivery.
+func (b *Backend) PingWebhook(ctx context.Context, repo proto.Repository, id int64) (webhook.Delivery, error) {
+	wh, err := b.Webhook(ctx, repo, id)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return b.pingWebhook(ctx, repo, wh)
+}
+
+func (b *Backend) pingWebhook(ctx context.Context, repo proto.Repository, wh webhook.Hook) (webhook.Delivery, error) {
+	ev, err := webhook.NewPingEvent(ctx, proto.UserFromContext(ctx), repo, wh)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	delID, err := webhook.PingWebhook(ctx, wh.Webhook, ev)
+	if err != nil {
+		return webhook.Delivery{}, err
 	}
 
-	return webhook.SendWebhook(ctx, wh, webhook.Event(delivery.Event), payload)
+	return b.WebhookDelivery(ctx, wh.ID, delID)
 }
 
 // WebhookDelivery returns a webhook delivery.
@@ -277,3 +331,15 @@ func (b *Backend) WebhookDelivery(ctx context.Context, webhookID int64, id uuid.
 
 	return delivery, nil
 }
+
+// SendEvent notifies the users watching the repository of an event and sends
+// the event to the webhooks. Only watchers that can read the repository are
+// notified.
+func (b *Backend) SendEvent(ctx context.Context, payload webhook.EventPayload) error {
+	// Watchers are notified even if delivering the webhooks fails.
+	nerr := webhook.NotifyWatchers(ctx, payload, func(repo string, u models.User) bool {
+		return b.AccessLevelForUser(ctx, repo, &user{user: u}) >= access.ReadOnlyAccess
+	})
+
+	return errors.Join(nerr, webhook.SendEvent(ctx, payload))
+}
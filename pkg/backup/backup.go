@@ -0,0 +1,487 @@
+// Package backup implements backups of a Soft Serve instance.
+//
+// A backup is a gzipped tar archive with a database dump, the data directory
+// files, and the configuration and SSH keys that live outside of it. The last
+// archive entry is a manifest with the checksums of all the other files.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/version"
+)
+
+var (
+	// ErrInvalidArchive is returned when a backup archive is malformed.
+	ErrInvalidArchive = errors.New("invalid backup archive")
+	// ErrChecksumMismatch is returned when the contents of a backup archive
+	// don't match its manifest.
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	// ErrNotEmpty is returned when restoring into a data directory that is
+	// already in use.
+	ErrNotEmpty = errors.New("data path is not empty")
+	// ErrDriverMismatch is returned when restoring a backup into an instance
+	// using a different database driver.
+	ErrDriverMismatch = errors.New("database driver mismatch")
+)
+
+// skipDirs are the data directory entries that are not backed up. They only
+// hold caches and logs, which are rebuilt as needed.
+var skipDirs = map[string]bool{
+	"cache":  true,
+	"log":    true,
+	"search": true,
+}
+
+// Backup writes a backup of the instance to w.
+//
+// The server can keep running during a backup. The database is snapshotted
+// first, then the repository references are copied before the git objects,
+// so every archived reference points to archived objects even when pushes
+// happen during the backup.
+func Backup(ctx context.Context, cfg *config.Config, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	m := &Manifest{
+		Version:       ManifestVersion,
+		ServerVersion: version.Version,
+		CreatedAt:     time.Now().UTC(),
+		DBDriver:      cfg.DB.Driver,
+	}
+
+	tmp, err := os.MkdirTemp("", "soft-serve-backup-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp) // nolint: errcheck
+
+	dump := filepath.Join(tmp, "db")
+	if err := dumpDatabase(ctx, cfg, dump); err != nil {
+		return err
+	}
+
+	if err := addFile(tw, m, dbEntryName(cfg.DB.Driver), dump); err != nil {
+		return err
+	}
+
+	// Add references and other small files first, and git objects last.
+	for _, objects := range []bool{false, true} {
+		if err := addDataPath(ctx, tw, m, cfg, objects); err != nil {
+			return err
+		}
+	}
+
+	for name, p := range externalFiles(cfg) {
+		if within(cfg.DataPath, p) {
+			continue
+		}
+
+		// Missing keys are generated on first start.
+		if err := skipRemoved(addFile(tw, m, name, p)); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0o644,
+		Size:    int64(len(manifest)),
+		ModTime: m.CreatedAt,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// addDataPath adds the data directory files to the archive. It adds either
+// the git object files, or all the other files and directories.
+func addDataPath(ctx context.Context, tw *tar.Writer, m *Manifest, cfg *config.Config, objects bool) error {
+	var dbFile string
+	if isSQLite(cfg.DB.Driver) {
+		dbFile = sqlitePath(cfg.DB.DataSource)
+	}
+
+	return filepath.WalkDir(cfg.DataPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files can be removed while walking, e.g. by git gc.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(cfg.DataPath, p)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+
+		if d.IsDir() && skipDirs[rel] {
+			return filepath.SkipDir
+		}
+
+		if dbFile != "" && strings.HasPrefix(p, dbFile) {
+			// The database, its WAL, and its journal are in the dump.
+			return nil
+		}
+
+		name := path.Join("data", rel)
+		if d.IsDir() {
+			if objects {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return skipRemoved(err)
+			}
+
+			return tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     name + "/",
+				Mode:     int64(info.Mode().Perm()),
+				ModTime:  info.ModTime(),
+			})
+		}
+
+		if !d.Type().IsRegular() || isTemp(d.Name()) || isObject(rel) != objects {
+			return nil
+		}
+
+		return skipRemoved(addFile(tw, m, name, p))
+	})
+}
+
+// addFile adds the file at p to the archive and the manifest.
+func addFile(tw *tar.Writer, m *Manifest, name string, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}); err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tw, h), io.LimitReader(f, info.Size()))
+	if err != nil {
+		return err
+	}
+
+	if n != info.Size() {
+		return fmt.Errorf("%s: file changed during backup", p)
+	}
+
+	m.Files = append(m.Files, File{
+		Name:   name,
+		Size:   n,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	})
+
+	return nil
+}
+
+// Restore restores a backup read from r into the instance. The data path
+// must be empty, except for the configuration file, which is kept.
+//
+// The archive checksums are verified before restoring the database. The
+// restored files are removed when the restore fails.
+func Restore(ctx context.Context, cfg *config.Config, r io.Reader) (m *Manifest, err error) {
+	if err := checkEmpty(cfg); err != nil {
+		return nil, err
+	}
+
+	_, statErr := os.Stat(cfg.DataPath)
+	createdDataPath := errors.Is(statErr, fs.ErrNotExist)
+	if err := os.MkdirAll(cfg.DataPath, 0o755); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "soft-serve-restore-*")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(tmp) // nolint: errcheck
+
+	var created []string
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, p := range created {
+			os.Remove(p) // nolint: errcheck
+		}
+
+		if createdDataPath {
+			os.RemoveAll(cfg.DataPath) // nolint: errcheck
+		} else {
+			cleanDataPath(cfg) // nolint: errcheck
+		}
+	}()
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	external := externalFiles(cfg)
+	files := map[string]File{}
+	dump := filepath.Join(tmp, "db")
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(hdr.Name, "/")
+		if m != nil {
+			return nil, fmt.Errorf("%w: unexpected %s after manifest", ErrInvalidArchive, name)
+		}
+
+		var target string
+		switch {
+		case name == manifestName:
+			m, err = readManifest(tr)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case name == dbEntryName(cfg.DB.Driver):
+			target = dump
+		case strings.HasPrefix(name, "data/") && filepath.IsLocal(strings.TrimPrefix(name, "data/")):
+			target = filepath.Join(cfg.DataPath, filepath.FromSlash(strings.TrimPrefix(name, "data/")))
+		case external[name] != "":
+			target = external[name]
+		case strings.HasPrefix(name, "database/"):
+			return nil, fmt.Errorf("%w: backup has %s, expected %s", ErrDriverMismatch, name, dbEntryName(cfg.DB.Driver))
+		default:
+			return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidArchive, name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, fs.FileMode(hdr.Mode).Perm()|0o700); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			f, ok, err := extractFile(tr, hdr, name, target, target == cfg.ConfigPath())
+			if ok && !within(cfg.DataPath, target) && !within(tmp, target) {
+				created = append(created, target)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			files[name] = f
+		default:
+			return nil, fmt.Errorf("%w: unsupported entry type %s", ErrInvalidArchive, name)
+		}
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("%w: missing manifest", ErrInvalidArchive)
+	}
+
+	if !isSQLite(m.DBDriver) || !isSQLite(cfg.DB.Driver) {
+		if m.DBDriver != cfg.DB.Driver {
+			return nil, fmt.Errorf("%w: backup uses %s, instance uses %s", ErrDriverMismatch, m.DBDriver, cfg.DB.Driver)
+		}
+	}
+
+	if err := m.verify(files); err != nil {
+		return nil, err
+	}
+
+	if err := restoreDatabase(ctx, cfg, dump); err != nil {
+		return nil, fmt.Errorf("restore database: %w", err)
+	}
+
+	return m, nil
+}
+
+// extractFile writes an archive file to target and returns its manifest
+// entry, and whether the file was created. Existing files are kept when keep
+// is true.
+func extractFile(r io.Reader, hdr *tar.Header, name string, target string, keep bool) (File, bool, error) {
+	var f *os.File
+	if _, err := os.Stat(target); err != nil || !keep {
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return File{}, false, err
+		}
+
+		f, err = os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fs.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return File{}, false, err
+		}
+
+		defer f.Close() // nolint: errcheck
+	}
+
+	w := io.Discard
+	if f != nil {
+		w = f
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), r)
+	if err != nil {
+		return File{}, f != nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	if f != nil {
+		if err := f.Close(); err != nil {
+			return File{}, true, err
+		}
+	}
+
+	return File{
+		Name:   name,
+		Size:   n,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	}, f != nil, nil
+}
+
+// externalFiles returns the archive entry names of the files that live
+// outside of the data path in some setups, mapped to their paths.
+func externalFiles(cfg *config.Config) map[string]string {
+	files := map[string]string{
+		"external/config.yaml": cfg.ConfigPath(),
+	}
+
+	for name, p := range map[string]string{
+		"ssh_host_key":   cfg.SSH.KeyPath,
+		"ssh_client_key": cfg.SSH.ClientKeyPath,
+	} {
+		if p == "" {
+			continue
+		}
+		files["external/"+name] = p
+		files["external/"+name+".pub"] = p + ".pub"
+	}
+
+	return files
+}
+
+// checkEmpty returns ErrNotEmpty when the data path has other files than
+// the configuration file.
+func checkEmpty(cfg *config.Config) error {
+	entries, err := os.ReadDir(cfg.DataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if filepath.Join(cfg.DataPath, e.Name()) != cfg.ConfigPath() {
+			return fmt.Errorf("%w: %s", ErrNotEmpty, cfg.DataPath)
+		}
+	}
+
+	return nil
+}
+
+// cleanDataPath removes everything in the data path except the
+// configuration file.
+func cleanDataPath(cfg *config.Config) error {
+	entries, err := os.ReadDir(cfg.DataPath)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		p := filepath.Join(cfg.DataPath, e.Name())
+		if p != cfg.ConfigPath() {
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// within returns whether p is in the directory dir.
+func within(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// isObject returns whether rel is a git object file of a repository.
+func isObject(rel string) bool {
+	return strings.HasPrefix(rel, "repos/") && strings.Contains(rel, ".git/objects/")
+}
+
+// isTemp returns whether name is a git lock or temporary file.
+func isTemp(name string) bool {
+	return strings.HasSuffix(name, ".lock") || strings.HasPrefix(name, "tmp_")
+}
+
+// skipRemoved ignores errors of files removed during the backup.
+func skipRemoved(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
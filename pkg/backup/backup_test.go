@@ -0,0 +1,179 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+func testConfig(t *testing.T, dataPath string) *config.Config {
+	t.Helper()
+	t.Setenv("SOFT_SERVE_CONFIG_LOCATION", "")
+	cfg := config.DefaultConfig()
+	cfg.DataPath = dataPath
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	return cfg
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testBackup(t *testing.T) []byte {
+	t.Helper()
+	ctx := context.TODO()
+	cfg := testConfig(t, t.TempDir())
+
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbx.ExecContext(ctx, "CREATE TABLE t (v TEXT); INSERT INTO t VALUES ('hello')"); err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close() // nolint: errcheck
+
+	writeFile(t, filepath.Join(cfg.DataPath, "config.yaml"), "name: test\n")
+	writeFile(t, filepath.Join(cfg.DataPath, "repos", "repo1.git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(cfg.DataPath, "repos", "repo1.git", "objects", "ab", "cdef"), "object")
+	writeFile(t, filepath.Join(cfg.DataPath, "repos", "repo1.git", "refs", "heads", "main.lock"), "lock")
+	writeFile(t, filepath.Join(cfg.DataPath, "lfs", "1", "objects", "ab", "cd", "abcd"), "lfs")
+	writeFile(t, filepath.Join(cfg.DataPath, "cache", "archives", "file"), "cache")
+
+	var buf bytes.Buffer
+	if err := Backup(ctx, cfg, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.TODO()
+	archive := testBackup(t)
+	cfg := testConfig(t, filepath.Join(t.TempDir(), "restored"))
+	m, err := Restore(ctx, cfg, bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.DBDriver != "sqlite" {
+		t.Errorf("expected sqlite driver, got %q", m.DBDriver)
+	}
+
+	for path, want := range map[string]string{
+		"config.yaml":                     "name: test\n",
+		"repos/repo1.git/HEAD":            "ref: refs/heads/main\n",
+		"repos/repo1.git/objects/ab/cdef": "object",
+		"lfs/1/objects/ab/cd/abcd":        "lfs",
+	} {
+		got, err := os.ReadFile(filepath.Join(cfg.DataPath, path))
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+		} else if string(got) != want {
+			t.Errorf("%s: expected %q, got %q", path, want, got)
+		}
+	}
+
+	for _, path := range []string{
+		"repos/repo1.git/refs/heads/main.lock",
+		"cache",
+	} {
+		if _, err := os.Stat(filepath.Join(cfg.DataPath, path)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s: expected not to be restored", path)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.DataPath, "repos", "repo1.git", "refs", "heads")); err != nil {
+		t.Errorf("expected empty directories to be restored: %v", err)
+	}
+
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close() // nolint: errcheck
+
+	var v string
+	if err := dbx.GetContext(ctx, &v, "SELECT v FROM t"); err != nil {
+		t.Fatal(err)
+	} else if v != "hello" {
+		t.Errorf("expected hello, got %q", v)
+	}
+
+	if _, err := Restore(ctx, cfg, bytes.NewReader(archive)); !errors.Is(err, ErrNotEmpty) {
+		t.Errorf("expected ErrNotEmpty, got %v", err)
+	}
+}
+
+func TestRestoreChecksumMismatch(t *testing.T) {
+	archive := testBackup(t)
+
+	// Rewrite the archive with a modified object.
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name == "data/repos/repo1.git/objects/ab/cdef" {
+			content = []byte("tampered")
+			hdr.Size = int64(len(content))
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := testConfig(t, filepath.Join(t.TempDir(), "restored"))
+	if _, err := Restore(context.TODO(), cfg, &buf); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	if _, err := os.Stat(cfg.DataPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the data path to be removed, got %v", err)
+	}
+}
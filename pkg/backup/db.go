@@ -0,0 +1,123 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+// isSQLite returns whether driver is a SQLite driver.
+func isSQLite(driver string) bool {
+	return strings.HasPrefix(driver, "sqlite")
+}
+
+// sqlitePath returns the database file path of a SQLite data source.
+func sqlitePath(dsn string) string {
+	dsn = strings.TrimPrefix(dsn, "file:")
+	if i := strings.IndexByte(dsn, '?'); i >= 0 {
+		dsn = dsn[:i]
+	}
+
+	return dsn
+}
+
+// dbEntryName returns the archive entry name of the database dump.
+func dbEntryName(driver string) string {
+	if isSQLite(driver) {
+		return "database/soft-serve.db"
+	}
+
+	return "database/soft-serve.pgdump"
+}
+
+// dumpDatabase writes a consistent snapshot of the database to path.
+//
+// SQLite databases are copied with VACUUM INTO, which reads the database in a
+// single transaction without blocking writers. Postgres databases are dumped
+// with pg_dump, which needs to be in PATH.
+func dumpDatabase(ctx context.Context, cfg *config.Config, path string) error {
+	switch {
+	case isSQLite(cfg.DB.Driver):
+		dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+
+		defer dbx.Close() // nolint: errcheck
+
+		if _, err := dbx.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+			return fmt.Errorf("snapshot database: %w", err)
+		}
+
+		return nil
+	case cfg.DB.Driver == "postgres":
+		return runPG(ctx, "pg_dump",
+			"--format=custom",
+			"--no-owner",
+			"--file="+path,
+			"--dbname="+cfg.DB.DataSource,
+		)
+	default:
+		return fmt.Errorf("unsupported database driver %q", cfg.DB.Driver)
+	}
+}
+
+// restoreDatabase restores a database dump made by dumpDatabase.
+func restoreDatabase(ctx context.Context, cfg *config.Config, path string) error {
+	switch {
+	case isSQLite(cfg.DB.Driver):
+		return copyFile(path, sqlitePath(cfg.DB.DataSource), 0o600)
+	case cfg.DB.Driver == "postgres":
+		return runPG(ctx, "pg_restore",
+			"--clean",
+			"--if-exists",
+			"--no-owner",
+			"--single-transaction",
+			"--dbname="+cfg.DB.DataSource,
+			path,
+		)
+	default:
+		return fmt.Errorf("unsupported database driver %q", cfg.DB.Driver)
+	}
+}
+
+// runPG runs a Postgres client program.
+func runPG(ctx context.Context, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// copyFile copies the file src to a new file dst.
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close() // nolint: errcheck
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close() // nolint: errcheck
+		return err
+	}
+
+	return out.Close()
+}
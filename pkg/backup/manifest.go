@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// ManifestVersion is the version of the backup manifest format.
+	ManifestVersion = 1
+
+	// manifestName is the name of the manifest in the archive. It is always
+	// the last archive entry since it holds the checksums of the other ones.
+	manifestName = "manifest.json"
+)
+
+// Manifest describes the contents of a backup archive.
+type Manifest struct {
+	// Version is the manifest format version.
+	Version int `json:"version"`
+	// ServerVersion is the version of the Soft Serve server that made the
+	// backup.
+	ServerVersion string `json:"server_version,omitempty"`
+	// CreatedAt is the time the backup started.
+	CreatedAt time.Time `json:"created_at"`
+	// DBDriver is the database driver of the backed up instance.
+	DBDriver string `json:"db_driver"`
+	// Files are the regular files of the archive.
+	Files []File `json:"files"`
+}
+
+// File is a file of a backup archive.
+type File struct {
+	// Name is the archive entry name.
+	Name string `json:"name"`
+	// Size is the file size in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded SHA-256 checksum of the file contents.
+	SHA256 string `json:"sha256"`
+}
+
+// Size returns the total size of the archived files in bytes.
+func (m *Manifest) Size() int64 {
+	var n int64
+	for _, f := range m.Files {
+		n += f.Size
+	}
+
+	return n
+}
+
+// verify checks that the archive files match the manifest.
+func (m *Manifest) verify(files map[string]File) error {
+	if len(files) != len(m.Files) {
+		return fmt.Errorf("%w: manifest lists %d files, archive has %d", ErrChecksumMismatch, len(m.Files), len(files))
+	}
+
+	for _, want := range m.Files {
+		got, ok := files[want.Name]
+		if !ok {
+			return fmt.Errorf("%w: missing %s", ErrChecksumMismatch, want.Name)
+		}
+		if got != want {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, want.Name)
+		}
+	}
+
+	return nil
+}
+
+// readManifest decodes a manifest.
+func readManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %v", ErrInvalidArchive, err)
+	}
+
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", ErrInvalidArchive, m.Version)
+	}
+
+	return &m, nil
+}
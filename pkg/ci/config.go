@@ -0,0 +1,69 @@
+package ci
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPath is the path of the CI pipeline configuration in a repository.
+const ConfigPath = ".soft-serve/ci.yaml"
+
+var (
+	// ErrNoConfig is returned when a commit doesn't have a pipeline
+	// configuration.
+	ErrNoConfig = errors.New("no ci pipeline found at " + ConfigPath)
+	// ErrNoSteps is returned when a pipeline doesn't have any steps.
+	ErrNoSteps = errors.New("ci pipeline has no steps")
+)
+
+// Config is a CI pipeline configuration.
+//
+//	env:
+//	  GOFLAGS: -mod=mod
+//	steps:
+//	  - name: build
+//	    run: go build ./...
+//	  - name: test
+//	    run: go test ./...
+type Config struct {
+	// Env is the environment of all steps.
+	Env map[string]string `yaml:"env"`
+	// Steps are the pipeline steps, run in order.
+	Steps []Step `yaml:"steps"`
+}
+
+// Step is a CI pipeline step.
+type Step struct {
+	// Name is the step name, defaults to the step number.
+	Name string `yaml:"name"`
+	// Run is the shell script of the step.
+	Run string `yaml:"run"`
+	// Env is the environment of the step.
+	Env map[string]string `yaml:"env"`
+}
+
+// ParseConfig parses a CI pipeline configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid ci pipeline: %w", err)
+	}
+
+	if len(cfg.Steps) == 0 {
+		return nil, ErrNoSteps
+	}
+
+	for i, s := range cfg.Steps {
+		if s.Name == "" {
+			cfg.Steps[i].Name = fmt.Sprintf("step %d", i+1)
+		}
+
+		if s.Run == "" {
+			return nil, fmt.Errorf("invalid ci pipeline: %s has nothing to run", cfg.Steps[i].Name)
+		}
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,58 @@
+package ci
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+env:
+  FOO: bar
+steps:
+  - name: build
+    run: make
+  - run: make test
+    env:
+      VERBOSE: "1"
+`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	if cfg.Env["FOO"] != "bar" {
+		t.Errorf("Env[FOO] = %q, want %q", cfg.Env["FOO"], "bar")
+	}
+
+	if len(cfg.Steps) != 2 {
+		t.Fatalf("len(Steps) = %d, want 2", len(cfg.Steps))
+	}
+
+	if cfg.Steps[0].Name != "build" || cfg.Steps[1].Name != "step 2" {
+		t.Errorf("step names = %q, %q, want %q, %q", cfg.Steps[0].Name, cfg.Steps[1].Name, "build", "step 2")
+	}
+
+	if cfg.Steps[1].Env["VERBOSE"] != "1" {
+		t.Errorf("Steps[1].Env[VERBOSE] = %q, want %q", cfg.Steps[1].Env["VERBOSE"], "1")
+	}
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	cases := map[string]string{
+		"syntax":   "steps: [",
+		"no steps": "env: {}",
+		"no run":   "steps:\n  - name: build",
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseConfig([]byte(data)); err == nil {
+				t.Error("ParseConfig() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := ParseConfig([]byte("steps: []")); !errors.Is(err, ErrNoSteps) {
+		t.Errorf("ParseConfig() error = %v, want %v", err, ErrNoSteps)
+	}
+}
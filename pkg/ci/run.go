@@ -0,0 +1,177 @@
+package ci
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+// Status is the status of a CI run.
+type Status string
+
+const (
+	// StatusQueued is the status of a run waiting for a runner.
+	StatusQueued Status = "queued"
+	// StatusRunning is the status of a running run.
+	StatusRunning Status = "running"
+	// StatusSuccess is the status of a run whose steps all succeeded.
+	StatusSuccess Status = "success"
+	// StatusFailure is the status of a run with a failed step.
+	StatusFailure Status = "failure"
+	// StatusError is the status of a run that couldn't complete, e.g. because
+	// of an invalid pipeline or a timeout.
+	StatusError Status = "error"
+)
+
+// Done returns whether the run is finished.
+func (s Status) Done() bool {
+	return s != StatusQueued && s != StatusRunning
+}
+
+// StepError is returned when a pipeline step fails.
+type StepError struct {
+	// Step is the name of the failed step.
+	Step string
+	// Err is the step error.
+	Err error
+}
+
+// Error implements error.
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the step error.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Options are the options of a CI run.
+type Options struct {
+	// ID is the run ID.
+	ID int64
+	// Repo is the repository name.
+	Repo string
+	// RepoPath is the path of the bare repository.
+	RepoPath string
+	// Commit is the SHA of the commit to run the pipeline of.
+	Commit string
+	// Ref is the pushed reference name.
+	Ref string
+	// Config is the pipeline configuration.
+	Config *Config
+	// Runner is a shell command the steps are run with. The step command is
+	// appended to it as arguments.
+	Runner string
+}
+
+// Run checks out the commit into a temporary worktree and runs the pipeline
+// steps in order, writing their output to w. It returns a StepError when a
+// step fails.
+//
+// Steps run with the worktree as their working directory, a temporary home
+// directory, and an environment that only has PATH from the server
+// environment. When a runner is set, steps are run through it so that they
+// can be isolated from the server. SOFT_SERVE_CI_WORKDIR is set to the run
+// directory holding the worktree, home, and temporary directories. The run
+// directory is removed when the run is done.
+func Run(ctx context.Context, opts Options, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "soft-serve-ci-")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	src := filepath.Join(dir, "src")
+	home := filepath.Join(dir, "home")
+	tmp := filepath.Join(dir, "tmp")
+	for _, p := range []string{home, tmp} {
+		if err := os.Mkdir(p, 0o700); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "==> checkout %s\n", opts.Commit) // nolint: errcheck
+	if err := checkout(ctx, opts.RepoPath, src, opts.Commit); err != nil {
+		return fmt.Errorf("checkout: %w", err)
+	}
+
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + home,
+		"TMPDIR=" + tmp,
+		"CI=true",
+		"SOFT_SERVE_CI=true",
+		"SOFT_SERVE_CI_RUN_ID=" + strconv.FormatInt(opts.ID, 10),
+		"SOFT_SERVE_CI_WORKDIR=" + dir,
+		"SOFT_SERVE_CI_COMMIT=" + opts.Commit,
+		"SOFT_SERVE_CI_REF=" + opts.Ref,
+		"SOFT_SERVE_REPO_NAME=" + opts.Repo,
+	}
+	env = append(env, environ(opts.Config.Env)...)
+
+	for _, step := range opts.Config.Steps {
+		fmt.Fprintf(w, "==> %s\n", step.Name) // nolint: errcheck
+
+		args := []string{"sh", "-c", step.Run}
+		if opts.Runner != "" {
+			// The runner is run by the shell so that it can use quotes and
+			// the run environment. The step command is passed as its
+			// arguments.
+			args = append([]string{"sh", "-c", opts.Runner + ` "$@"`, "sh"}, args...)
+		}
+
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = src
+		cmd.Env = append(append([]string{}, env...), environ(step.Env)...)
+		cmd.Stdout = w
+		cmd.Stderr = w
+		cmd.WaitDelay = time.Second
+
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return &StepError{Step: step.Name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// checkout clones the repository at path into dst and checks out commit.
+func checkout(ctx context.Context, path string, dst string, commit string) error {
+	if err := git.Clone(path, dst, git.CloneOptions{
+		Quiet: true,
+		CommandOptions: git.CommandOptions{
+			Timeout: -1,
+			Context: ctx,
+		},
+	}); err != nil {
+		return err
+	}
+
+	_, err := git.NewCommand("checkout", "--quiet", "--detach", commit).WithContext(ctx).RunInDir(dst)
+	return err
+}
+
+// environ returns a sorted list of environment variables.
+func environ(env map[string]string) []string {
+	envs := make([]string, 0, len(env))
+	for k, v := range env {
+		envs = append(envs, k+"="+v)
+	}
+
+	sort.Strings(envs)
+	return envs
+}
@@ -0,0 +1,109 @@
+package ci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func testRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+	return dir, git("rev-parse", "HEAD")
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("steps are shell scripts")
+	}
+
+	path, sha := testRepo(t)
+	cfg := &Config{
+		Env: map[string]string{"GREETING": "hi"},
+		Steps: []Step{
+			{Name: "cat", Run: "cat hello.txt"},
+			{Name: "env", Run: `echo "$GREETING $NAME $CI $SOFT_SERVE_CI_COMMIT"`, Env: map[string]string{"NAME": "ci"}},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := Run(context.Background(), Options{ID: 1, Repo: "repo1", RepoPath: path, Commit: sha, Ref: "refs/heads/main", Config: cfg}, &out); err != nil {
+		t.Fatalf("Run() error = %v, output: %s", err, out.String())
+	}
+
+	for _, want := range []string{"==> cat\nhello\n", "==> env\nhi ci true " + sha + "\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
+
+func TestRunStepFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("steps are shell scripts")
+	}
+
+	path, sha := testRepo(t)
+	cfg := &Config{
+		Steps: []Step{
+			{Name: "fail", Run: "exit 3"},
+			{Name: "skipped", Run: "echo skipped"},
+		},
+	}
+
+	var out bytes.Buffer
+	err := Run(context.Background(), Options{RepoPath: path, Commit: sha, Config: cfg}, &out)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != "fail" {
+		t.Fatalf("Run() error = %v, want a StepError of step fail", err)
+	}
+
+	if strings.Contains(out.String(), "skipped") {
+		t.Errorf("output = %q, want later steps skipped", out.String())
+	}
+}
+
+func TestRunRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("steps are shell scripts")
+	}
+
+	path, sha := testRepo(t)
+	cfg := &Config{
+		Steps: []Step{
+			{Name: "runner", Run: `echo "$RUNNER $1" && test -d "$SOFT_SERVE_CI_WORKDIR/src"`},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := Run(context.Background(), Options{RepoPath: path, Commit: sha, Config: cfg, Runner: `env RUNNER="in runner"`}, &out); err != nil {
+		t.Fatalf("Run() error = %v, output: %s", err, out.String())
+	}
+
+	if want := "==> runner\nin runner \n"; !strings.Contains(out.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", out.String(), want)
+	}
+}
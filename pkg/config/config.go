@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"golang.org/x/crypto/ssh"
 	"gopkg.in/yaml.v3"
@@ -110,9 +111,82 @@ type LFSConfig struct {
 	SSHEnabled bool `env:"SSH_ENABLED" yaml:"ssh_enabled"`
 }
 
+// IPConfig is the configuration for IP based access rules.
+// These rules apply to all repositories and are enforced on SSH, HTTP, and
+// Git daemon connections.
+type IPConfig struct {
+	// Allow is a list of CIDRs or IP addresses allowed to connect.
+	// If empty, all addresses are allowed unless denied.
+	Allow []string `env:"ALLOW" envSeparator:"," yaml:"allow"`
+
+	// Deny is a list of CIDRs or IP addresses denied from connecting.
+	// Deny rules take precedence over allow rules.
+	Deny []string `env:"DENY" envSeparator:"," yaml:"deny"`
+}
+
 // JobsConfig is the configuration for cron jobs.
 type JobsConfig struct {
-	MirrorPull string `env:"MIRROR_PULL" yaml:"mirror_pull"`
+	MirrorPull        string `env:"MIRROR_PULL" yaml:"mirror_pull"`
+	WebhookDeliveries string `env:"WEBHOOK_DELIVERIES" yaml:"webhook_deliveries"`
+	CI                string `env:"CI" yaml:"ci"`
+	Maintenance       string `env:"MAINTENANCE" yaml:"maintenance"`
+	Trash             string `env:"TRASH" yaml:"trash"`
+	ArchiveCache      string `env:"ARCHIVE_CACHE" yaml:"archive_cache"`
+}
+
+// WebhookConfig is the configuration for webhook deliveries.
+type WebhookConfig struct {
+	// Workers is the number of concurrent webhook deliveries.
+	Workers int `env:"WORKERS" yaml:"workers"`
+
+	// MaxAttempts is the number of times a delivery is attempted before it's
+	// marked as failed.
+	MaxAttempts int `env:"MAX_ATTEMPTS" yaml:"max_attempts"`
+}
+
+// CIConfig is the configuration for the built-in CI runner.
+type CIConfig struct {
+	// Enabled is whether pushes run the repository CI pipeline.
+	Enabled bool `env:"ENABLED" yaml:"enabled"`
+
+	// Workers is the number of concurrent CI runs.
+	Workers int `env:"WORKERS" yaml:"workers"`
+
+	// Timeout is the maximum duration of a CI run in seconds.
+	Timeout int `env:"TIMEOUT" yaml:"timeout"`
+
+	// Runner is a shell command that runs the pipeline steps, e.g. in a
+	// container or as another user. The step command is appended to it.
+	// Steps run directly on the server when it's empty.
+	Runner string `env:"RUNNER" yaml:"runner"`
+}
+
+// MaintenanceConfig is the configuration for scheduled repository
+// maintenance.
+type MaintenanceConfig struct {
+	// Workers is the number of repositories maintained concurrently.
+	Workers int `env:"WORKERS" yaml:"workers"`
+
+	// LooseObjects is the number of loose objects above which a repository
+	// is repacked.
+	LooseObjects int `env:"LOOSE_OBJECTS" yaml:"loose_objects"`
+
+	// Packs is the number of packs above which a repository is repacked.
+	Packs int `env:"PACKS" yaml:"packs"`
+}
+
+// TrashConfig is the configuration for deleted repositories.
+type TrashConfig struct {
+	// Retention is the number of days deleted repositories are kept before
+	// they're purged. Zero deletes repositories immediately.
+	Retention int `env:"RETENTION" yaml:"retention"`
+}
+
+// ArchiveCacheConfig is the configuration for cached repository archives.
+type ArchiveCacheConfig struct {
+	// Retention is the number of days an archive is cached before it's
+	// removed by the archive cache job.
+	Retention int `env:"RETENTION" yaml:"retention"`
 }
 
 // Config is the configuration for Soft Serve.
@@ -144,6 +218,24 @@ type Config struct {
 	// Jobs is the configuration for cron jobs
 	Jobs JobsConfig `envPrefix:"JOBS_" yaml:"jobs"`
 
+	// IP is the configuration for IP based access rules.
+	IP IPConfig `envPrefix:"IP_" yaml:"ip"`
+
+	// Webhook is the configuration for webhook deliveries.
+	Webhook WebhookConfig `envPrefix:"WEBHOOK_" yaml:"webhook"`
+
+	// CI is the configuration for the built-in CI runner.
+	CI CIConfig `envPrefix:"CI_" yaml:"ci"`
+
+	// Maintenance is the configuration for scheduled repository maintenance.
+	Maintenance MaintenanceConfig `envPrefix:"MAINTENANCE_" yaml:"maintenance"`
+
+	// Trash is the configuration for deleted repositories.
+	Trash TrashConfig `envPrefix:"TRASH_" yaml:"trash"`
+
+	// ArchiveCache is the configuration for cached repository archives.
+	ArchiveCache ArchiveCacheConfig `envPrefix:"ARCHIVE_CACHE_" yaml:"archive_cache"`
+
 	// InitialAdminKeys is a list of public keys that will be added to the list of admins.
 	InitialAdminKeys []string `env:"INITIAL_ADMIN_KEYS" envSeparator:"\n" yaml:"initial_admin_keys"`
 
@@ -188,6 +280,24 @@ func (c *Config) Environ() []string {
 		fmt.Sprintf("SOFT_SERVE_LFS_ENABLED=%t", c.LFS.Enabled),
 		fmt.Sprintf("SOFT_SERVE_LFS_SSH_ENABLED=%t", c.LFS.SSHEnabled),
 		fmt.Sprintf("SOFT_SERVE_JOBS_MIRROR_PULL=%s", c.Jobs.MirrorPull),
+		fmt.Sprintf("SOFT_SERVE_JOBS_WEBHOOK_DELIVERIES=%s", c.Jobs.WebhookDeliveries),
+		fmt.Sprintf("SOFT_SERVE_JOBS_CI=%s", c.Jobs.CI),
+		fmt.Sprintf("SOFT_SERVE_JOBS_MAINTENANCE=%s", c.Jobs.Maintenance),
+		fmt.Sprintf("SOFT_SERVE_JOBS_TRASH=%s", c.Jobs.Trash),
+		fmt.Sprintf("SOFT_SERVE_JOBS_ARCHIVE_CACHE=%s", c.Jobs.ArchiveCache),
+		fmt.Sprintf("SOFT_SERVE_IP_ALLOW=%s", strings.Join(c.IP.Allow, ",")),
+		fmt.Sprintf("SOFT_SERVE_IP_DENY=%s", strings.Join(c.IP.Deny, ",")),
+		fmt.Sprintf("SOFT_SERVE_WEBHOOK_WORKERS=%d", c.Webhook.Workers),
+		fmt.Sprintf("SOFT_SERVE_WEBHOOK_MAX_ATTEMPTS=%d", c.Webhook.MaxAttempts),
+		fmt.Sprintf("SOFT_SERVE_CI_ENABLED=%t", c.CI.Enabled),
+		fmt.Sprintf("SOFT_SERVE_CI_WORKERS=%d", c.CI.Workers),
+		fmt.Sprintf("SOFT_SERVE_CI_TIMEOUT=%d", c.CI.Timeout),
+		fmt.Sprintf("SOFT_SERVE_CI_RUNNER=%s", c.CI.Runner),
+		fmt.Sprintf("SOFT_SERVE_MAINTENANCE_WORKERS=%d", c.Maintenance.Workers),
+		fmt.Sprintf("SOFT_SERVE_MAINTENANCE_LOOSE_OBJECTS=%d", c.Maintenance.LooseObjects),
+		fmt.Sprintf("SOFT_SERVE_MAINTENANCE_PACKS=%d", c.Maintenance.Packs),
+		fmt.Sprintf("SOFT_SERVE_TRASH_RETENTION=%d", c.Trash.Retention),
+		fmt.Sprintf("SOFT_SERVE_ARCHIVE_CACHE_RETENTION=%d", c.ArchiveCache.Retention),
 	}...)
 
 	return envs
@@ -353,7 +463,31 @@ func DefaultConfig() *Config {
 			SSHEnabled: false,
 		},
 		Jobs: JobsConfig{
-			MirrorPull: "@every 10m",
+			MirrorPull:        "@every 10m",
+			WebhookDeliveries: "@every 10s",
+			CI:                "@every 5s",
+			Maintenance:       "@every 1h",
+			Trash:             "@every 1h",
+			ArchiveCache:      "@every 1h",
+		},
+		Webhook: WebhookConfig{
+			Workers:     4,
+			MaxAttempts: 5,
+		},
+		CI: CIConfig{
+			Workers: 2,
+			Timeout: 600,
+		},
+		Maintenance: MaintenanceConfig{
+			Workers:      1,
+			LooseObjects: 1000,
+			Packs:        10,
+		},
+		Trash: TrashConfig{
+			Retention: 30,
+		},
+		ArchiveCache: ArchiveCacheConfig{
+			Retention: 7,
 		},
 	}
 }
@@ -393,6 +527,50 @@ func (c *Config) Validate() error {
 		c.DB.DataSource = filepath.Join(c.DataPath, c.DB.DataSource)
 	}
 
+	// Use the default webhook delivery settings when unset
+	if c.Webhook.Workers < 1 {
+		c.Webhook.Workers = DefaultConfig().Webhook.Workers
+	}
+
+	if c.Webhook.MaxAttempts < 1 {
+		c.Webhook.MaxAttempts = DefaultConfig().Webhook.MaxAttempts
+	}
+
+	// Use the default CI settings when unset
+	if c.CI.Workers < 1 {
+		c.CI.Workers = DefaultConfig().CI.Workers
+	}
+
+	if c.CI.Timeout < 1 {
+		c.CI.Timeout = DefaultConfig().CI.Timeout
+	}
+
+	// Use the default maintenance settings when unset
+	if c.Maintenance.Workers < 1 {
+		c.Maintenance.Workers = DefaultConfig().Maintenance.Workers
+	}
+
+	if c.Maintenance.LooseObjects < 1 {
+		c.Maintenance.LooseObjects = DefaultConfig().Maintenance.LooseObjects
+	}
+
+	if c.Maintenance.Packs < 1 {
+		c.Maintenance.Packs = DefaultConfig().Maintenance.Packs
+	}
+
+	if c.Trash.Retention < 0 {
+		return fmt.Errorf("trash retention must be zero or positive")
+	}
+
+	if c.ArchiveCache.Retention < 1 {
+		c.ArchiveCache.Retention = DefaultConfig().ArchiveCache.Retention
+	}
+
+	// Validate IP rules
+	if _, err := access.ParseIPRules(c.IP.Allow, c.IP.Deny); err != nil {
+		return fmt.Errorf("ip rules: %w", err)
+	}
+
 	// Validate keys
 	pks := make([]string, 0)
 	for _, key := range parseAuthKeys(c.InitialAdminKeys) {
@@ -105,6 +105,73 @@ lfs:
 # Cron job configuration
 jobs:
   mirror_pull: "{{ .Jobs.MirrorPull }}"
+  webhook_deliveries: "{{ .Jobs.WebhookDeliveries }}"
+  ci: "{{ .Jobs.CI }}"
+  maintenance: "{{ .Jobs.Maintenance }}"
+  trash: "{{ .Jobs.Trash }}"
+  archive_cache: "{{ .Jobs.ArchiveCache }}"
+
+# Webhook delivery configuration.
+# Deliveries are queued and sent by the server in the background. Failed
+# deliveries are retried with an exponential backoff.
+webhook:
+  # The number of concurrent deliveries.
+  workers: {{ .Webhook.Workers }}
+  # The number of attempts before a delivery is marked as failed.
+  max_attempts: {{ .Webhook.MaxAttempts }}
+
+# Built-in CI runner configuration.
+# When enabled, pushes to branches with a ".soft-serve/ci.yaml" file queue a CI
+# run of the pushed commit. Steps run as the server user, so only enable this
+# when you trust everyone who can push.
+ci:
+  # Enable the CI runner.
+  enabled: {{ .CI.Enabled }}
+  # The number of concurrent runs.
+  workers: {{ .CI.Workers }}
+  # The maximum duration of a run in seconds.
+  timeout: {{ .CI.Timeout }}
+  # The shell command that runs the steps, e.g. in a container or as another
+  # user. The step command is appended to it, and $SOFT_SERVE_CI_WORKDIR is
+  # the run directory holding the worktree. Leave empty to run the steps as
+  # the server user.
+  #runner: "{{ .CI.Runner }}"
+
+# Repository maintenance configuration.
+# The maintenance job repacks repositories with too many loose objects or
+# packs, and writes their commit-graph and bitmap index.
+maintenance:
+  # The number of repositories maintained concurrently.
+  workers: {{ .Maintenance.Workers }}
+  # The number of loose objects above which a repository is repacked.
+  loose_objects: {{ .Maintenance.LooseObjects }}
+  # The number of packs above which a repository is repacked.
+  packs: {{ .Maintenance.Packs }}
+
+# Deleted repositories configuration.
+# Deleted repositories are moved to the trash, where admins can restore them
+# until they're purged by the trash job.
+trash:
+  # The number of days deleted repositories are kept. Set to 0 to delete
+  # repositories immediately.
+  retention: {{ .Trash.Retention }}
+
+# Cached repository archives configuration.
+# Archives of tags downloaded over HTTP are cached, and the archive cache job
+# removes them once they're older than the retention period.
+archive_cache:
+  # The number of days an archive is cached.
+  retention: {{ .ArchiveCache.Retention }}
+
+# IP based access rules.
+# These apply to all repositories on the SSH, HTTP, and Git daemon servers.
+# Entries can be CIDRs or single IP addresses. Deny rules take precedence, and
+# when any allow rules are set, only matching addresses are allowed.
+ip:
+  allow:{{ range .IP.Allow }}
+    - "{{ . }}"{{ else }} []{{ end }}
+  deny:{{ range .IP.Deny }}
+    - "{{ . }}"{{ else }} []{{ end }}
 
 # Additional admin keys.
 #initial_admin_keys:
@@ -35,6 +35,13 @@ var (
 		Name:      "git_upload_archive_total",
 		Help:      "The total number of git-upload-archive requests",
 	}, []string{"repo"})
+
+	receivePackGitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "git",
+		Name:      "git_receive_pack_total",
+		Help:      "The total number of git-receive-pack requests",
+	}, []string{"repo"})
 )
 
 // ErrServerClosed indicates that the server has been closed.
@@ -190,6 +197,8 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			counter = uploadPackGitCounter
 		case git.UploadArchiveService:
 			counter = uploadArchiveGitCounter
+		case git.ReceivePackService:
+			counter = receivePackGitCounter
 		default:
 			d.fatal(c, git.ErrInvalidRequest)
 			return
@@ -202,8 +211,12 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		}
 
 		host := strings.TrimPrefix(string(opts[1]), "host=")
-		extraParams := map[string]string{}
 
+		// Extra parameters are passed down to git as a colon separated list
+		// in the GIT_PROTOCOL environment variable, keeping the order in
+		// which the client sent them.
+		// See https://git-scm.com/docs/pack-protocol#_extra_parameters
+		var extraParams []string
 		if len(opts) > 2 {
 			buf := bytes.TrimPrefix(opts[2], []byte{0})
 			for _, o := range bytes.Split(buf, []byte{0}) {
@@ -212,19 +225,18 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 					continue
 				}
 
-				kv := strings.SplitN(opt, "=", 2)
-				if len(kv) != 2 {
+				if !strings.Contains(opt, "=") {
 					d.logger.Errorf("git: invalid option %q", opt)
 					continue
 				}
 
-				extraParams[kv[0]] = kv[1]
+				extraParams = append(extraParams, opt)
 			}
+		}
 
-			version := extraParams["version"]
-			if version != "" {
-				d.logger.Debugf("git: protocol version %s", version)
-			}
+		gitProto := strings.Join(extraParams, ":")
+		if version := git.ProtocolVersion(gitProto); version > 0 {
+			d.logger.Debugf("git: protocol version %d", version)
 		}
 
 		be := d.be
@@ -247,7 +259,13 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			return
 		}
 
-		if _, err := d.be.Repository(ctx, repo); err != nil {
+		if !be.AllowAddr(ctx, name, c.RemoteAddr().String()) {
+			d.fatal(c, git.ErrAddrNotAllowed)
+			return
+		}
+
+		rr, err := d.be.Repository(ctx, repo)
+		if err != nil {
 			d.fatal(c, git.ErrInvalidRepo)
 			return
 		}
@@ -258,6 +276,27 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			return
 		}
 
+		// Anonymous pushes are only allowed for repositories that opt in,
+		// and only when anonymous users have write access.
+		if service == git.ReceivePackService {
+			daemonPush, err := be.DaemonPush(ctx, name)
+			if err != nil {
+				d.logger.Debugf("git: error getting daemon push: %v", err)
+				d.fatal(c, git.ErrSystemMalfunction)
+				return
+			}
+
+			if !daemonPush || auth < access.ReadWriteAccess {
+				d.fatal(c, git.ErrNotAuthed)
+				return
+			}
+
+			if rr.IsArchived() {
+				d.fatal(c, git.ErrArchived)
+				return
+			}
+		}
+
 		// Environment variables to pass down to git hooks.
 		envs := []string{
 			"SOFT_SERVE_REPO_NAME=" + name,
@@ -267,15 +306,8 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		}
 
 		// Add git protocol environment variable.
-		if len(extraParams) > 0 {
-			var gitProto string
-			for k, v := range extraParams {
-				if len(gitProto) > 0 {
-					gitProto += ":"
-				}
-				gitProto += k + "=" + v
-			}
-			envs = append(envs, "GIT_PROTOCOL="+gitProto)
+		if len(gitProto) > 0 {
+			envs = append(envs, git.ProtocolEnv+"="+gitProto)
 		}
 
 		envs = append(envs, d.cfg.Environ()...)
@@ -294,6 +326,12 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			return
 		}
 
+		if service == git.ReceivePackService {
+			if err := git.EnsureDefaultBranch(ctx, cmd.Dir); err != nil {
+				d.logger.Debugf("git: error ensuring default branch: %v", err)
+			}
+		}
+
 		counter.WithLabelValues(name)
 	}
 }
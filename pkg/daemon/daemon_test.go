@@ -6,6 +6,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
 	"github.com/charmbracelet/soft-serve/pkg/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/store"
 	"github.com/charmbracelet/soft-serve/pkg/store/database"
 	"github.com/charmbracelet/soft-serve/pkg/test"
@@ -46,6 +49,7 @@ func TestMain(m *testing.M) {
 		log.Fatal(err)
 	}
 	defer dbx.Close() // nolint: errcheck
+	ctx = db.WithContext(ctx, dbx)
 	if err := migrate.Migrate(ctx, dbx); err != nil {
 		log.Fatal(err)
 	}
@@ -100,6 +104,119 @@ func TestInvalidRepo(t *testing.T) {
 	}
 }
 
+func TestDeniedAddr(t *testing.T) {
+	testDaemon.cfg.IP.Deny = []string{"127.0.0.0/8", "::1"}
+	defer func() { testDaemon.cfg.IP.Deny = nil }()
+	c, err := net.Dial("tcp", testDaemon.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pktline.NewEncoder(c).EncodeString("git-upload-pack /test.git\x00"); err != nil {
+		t.Fatalf("expected nil, got error: %v", err)
+	}
+	_, err = readPktline(c)
+	if err == nil || err.Error() != git.ErrAddrNotAllowed.Error() {
+		t.Errorf("expected %q error, got %v", git.ErrAddrNotAllowed, err)
+	}
+}
+
+func TestProtocolV2LsRefs(t *testing.T) {
+	ctx := testDaemon.ctx
+	admin, err := testDaemon.be.User(ctx, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testDaemon.be.CreateRepository(ctx, "v2", admin, proto.RepositoryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rp := filepath.Join(testDaemon.cfg.DataPath, "repos", "v2.git")
+	// 4b825dc642cb6eb9a060e54bf8d69288fbee4904 is the empty tree.
+	cmd := exec.Command("git", "commit-tree", "4b825dc642cb6eb9a060e54bf8d69288fbee4904", "-m", "init")
+	cmd.Dir = rp
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := strings.TrimSpace(string(out))
+	for _, ref := range []string{"refs/heads/master", "refs/heads/feature", "refs/tags/v1.0.0"} {
+		cmd := exec.Command("git", "update-ref", ref, commit)
+		cmd.Dir = rp
+		if err := cmd.Run(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := net.Dial("tcp", testDaemon.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close() // nolint: errcheck
+
+	enc := pktline.NewEncoder(c)
+	if err := enc.EncodeString("git-upload-pack /v2.git\x00host=localhost\x00\x00version=2\x00"); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := pktline.NewScanner(c)
+	caps := readUntilFlush(t, scanner)
+	if len(caps) == 0 || caps[0] != "version 2" {
+		t.Fatalf("expected protocol v2 capability advertisement, got %q", caps)
+	}
+	var lsRefs bool
+	for _, c := range caps {
+		if strings.HasPrefix(c, "ls-refs") {
+			lsRefs = true
+		}
+	}
+	if !lsRefs {
+		t.Fatalf("expected ls-refs capability, got %q", caps)
+	}
+
+	// Request the branches only using a ref-prefix.
+	if err := enc.EncodeString("command=ls-refs\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Write([]byte("0001")); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.EncodeString("ref-prefix refs/heads/\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	refs := readUntilFlush(t, scanner)
+	want := []string{
+		commit + " refs/heads/feature",
+		commit + " refs/heads/master",
+	}
+	if strings.Join(refs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected refs %q, got %q", want, refs)
+	}
+}
+
+func readUntilFlush(t *testing.T, s *pktline.Scanner) []string {
+	t.Helper()
+	var lines []string
+	for s.Scan() {
+		line := strings.TrimSpace(string(s.Bytes()))
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
 func readPktline(c net.Conn) (string, error) {
 	pktout := pktline.NewScanner(c)
 	if !pktout.Scan() {
@@ -0,0 +1,366 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrCountMismatch is returned when a copied table doesn't have the same
+// number of rows as the source table.
+var ErrCountMismatch = errors.New("row count mismatch")
+
+// TableCopy is the result of copying a table.
+type TableCopy struct {
+	// Name is the table name.
+	Name string
+	// Rows is the number of copied rows.
+	Rows int64
+}
+
+// Copy copies the rows of every table of src, except the migrations table,
+// to dst, preserving IDs and sequences. Both databases must have the same
+// schema. The existing rows of dst are removed.
+//
+// The source is read in a single transaction, so src can be in use while
+// copying. All the rows are written in a single transaction, and the row
+// counts of both databases are compared before committing.
+func Copy(ctx context.Context, dst *DB, src *DB) ([]TableCopy, error) {
+	srcTx, err := src.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: isolation(src.DriverName()),
+		ReadOnly:  src.DriverName() == "postgres",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer srcTx.Rollback() // nolint: errcheck
+
+	stx := &Tx{srcTx, src.logger}
+	var copies []TableCopy
+	err = dst.TransactionContext(ctx, func(tx *Tx) error {
+		tables, err := orderedTables(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		// Remove the rows created by the migrations, children first.
+		for i := len(tables) - 1; i >= 0; i-- {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM "+quote(tables[i])); err != nil {
+				return fmt.Errorf("%s: %w", tables[i], err)
+			}
+		}
+
+		for _, table := range tables {
+			n, err := copyTable(ctx, tx, stx, table)
+			if err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+
+			copies = append(copies, TableCopy{Name: table, Rows: n})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+// isolation returns the isolation level used to read a consistent snapshot.
+func isolation(driver string) sql.IsolationLevel {
+	if driver == "postgres" {
+		return sql.LevelRepeatableRead
+	}
+
+	// SQLite transactions are always serializable.
+	return sql.LevelDefault
+}
+
+// copyTable copies the rows of a table and its sequence, and returns the
+// number of copied rows.
+func copyTable(ctx context.Context, dst *Tx, src *Tx, table string) (int64, error) {
+	types, err := columnTypes(ctx, dst, table)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := src.QueryxContext(ctx, "SELECT * FROM "+quote(table))
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	quoted := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = quote(c)
+	}
+
+	insert := dst.Rebind(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quote(table),
+		strings.Join(quoted, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", "),
+	))
+
+	var n int64
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return 0, err
+		}
+
+		for i, v := range values {
+			values[i] = convert(v, types[cols[i]])
+		}
+
+		if _, err := dst.ExecContext(ctx, insert, values...); err != nil {
+			return 0, err
+		}
+
+		n++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var want, got int64
+	count := "SELECT COUNT(*) FROM " + quote(table)
+	if err := src.GetContext(ctx, &want, count); err != nil {
+		return 0, err
+	}
+
+	if err := dst.GetContext(ctx, &got, count); err != nil {
+		return 0, err
+	}
+
+	if got != want || n != want {
+		return 0, fmt.Errorf("%w: expected %d rows, got %d", ErrCountMismatch, want, got)
+	}
+
+	// Only integer IDs are generated from a sequence, webhook deliveries for
+	// instance use UUIDs.
+	if strings.Contains(types["id"], "int") {
+		seq, err := sequence(ctx, src, table)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := setSequence(ctx, dst, table, seq); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// convert converts a value read from one driver to the column type of the
+// other. SQLite stores booleans as integers, and the Postgres driver sends
+// byte slices as bytea.
+func convert(v any, typ string) any {
+	switch v := v.(type) {
+	case int64:
+		if typ == "boolean" {
+			return v != 0
+		}
+	case []byte:
+		if typ != "bytea" && typ != "blob" {
+			return string(v)
+		}
+	}
+
+	return v
+}
+
+// columnTypes returns the lowercased column types of a table.
+func columnTypes(ctx context.Context, tx *Tx, table string) (map[string]string, error) {
+	var query string
+	switch tx.DriverName() {
+	case "sqlite3", "sqlite":
+		query = "SELECT name, type FROM pragma_table_info(?)"
+	case "postgres":
+		query = "SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ?"
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", tx.DriverName())
+	}
+
+	rows, err := tx.QueryContext(ctx, tx.Rebind(query), table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	types := map[string]string{}
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, err
+		}
+		types[name] = strings.ToLower(typ)
+	}
+
+	return types, rows.Err()
+}
+
+// orderedTables returns the tables of the database, except the migrations
+// table, with referenced tables before the tables referencing them.
+func orderedTables(ctx context.Context, tx *Tx) ([]string, error) {
+	var tablesQuery, refsQuery string
+	switch tx.DriverName() {
+	case "sqlite3", "sqlite":
+		tablesQuery = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+		refsQuery = `SELECT "table" FROM pragma_foreign_key_list(?)`
+	case "postgres":
+		tablesQuery = "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'"
+		refsQuery = `SELECT ccu.table_name FROM information_schema.table_constraints tc
+			JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema() AND tc.table_name = ?`
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", tx.DriverName())
+	}
+
+	var tables []string
+	if err := tx.SelectContext(ctx, &tables, tablesQuery); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(tables)
+	refs := map[string][]string{}
+	for _, t := range tables {
+		var r []string
+		if err := tx.SelectContext(ctx, &r, tx.Rebind(refsQuery), t); err != nil {
+			return nil, err
+		}
+		refs[t] = r
+	}
+
+	// Depth first topological sort, keeping the alphabetical order of
+	// independent tables.
+	ordered := make([]string, 0, len(tables))
+	state := map[string]int{}
+	var visit func(t string) error
+	visit = func(t string) error {
+		switch state[t] {
+		case 1:
+			return fmt.Errorf("circular foreign keys on table %s", t)
+		case 2:
+			return nil
+		}
+
+		state[t] = 1
+		for _, r := range refs[t] {
+			if r == t {
+				continue
+			}
+			if err := visit(r); err != nil {
+				return err
+			}
+		}
+
+		state[t] = 2
+		if t != "migrations" {
+			ordered = append(ordered, t)
+		}
+
+		return nil
+	}
+
+	for _, t := range tables {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// sequence returns the last ID generated for a table, or the largest ID if
+// the table doesn't have a sequence.
+func sequence(ctx context.Context, tx *Tx, table string) (int64, error) {
+	var last int64
+	if err := tx.GetContext(ctx, &last, "SELECT COALESCE(MAX(id), 0) FROM "+quote(table)); err != nil {
+		return 0, err
+	}
+
+	var seq sql.NullInt64
+	switch tx.DriverName() {
+	case "sqlite3", "sqlite":
+		err := tx.GetContext(ctx, &seq, "SELECT seq FROM sqlite_sequence WHERE name = ?", table)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+	case "postgres":
+		var name sql.NullString
+		if err := tx.GetContext(ctx, &name, "SELECT pg_get_serial_sequence($1, 'id')", table); err != nil {
+			return 0, err
+		}
+
+		if name.Valid {
+			query := "SELECT CASE WHEN is_called THEN last_value ELSE last_value - 1 END FROM " + name.String
+			if err := tx.GetContext(ctx, &seq, query); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if seq.Valid && seq.Int64 > last {
+		last = seq.Int64
+	}
+
+	return last, nil
+}
+
+// setSequence sets the last ID generated for a table.
+func setSequence(ctx context.Context, tx *Tx, table string, last int64) error {
+	switch tx.DriverName() {
+	case "sqlite3", "sqlite":
+		// Tables without AUTOINCREMENT don't have a sequence.
+		var n int
+		if err := tx.GetContext(ctx, &n, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ? AND sql LIKE '%AUTOINCREMENT%'", table); err != nil || n == 0 {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM sqlite_sequence WHERE name = ?", table); err != nil {
+			return err
+		}
+
+		if last > 0 {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO sqlite_sequence (name, seq) VALUES (?, ?)", table, last); err != nil {
+				return err
+			}
+		}
+	case "postgres":
+		var name sql.NullString
+		if err := tx.GetContext(ctx, &name, "SELECT pg_get_serial_sequence($1, 'id')", table); err != nil || !name.Valid {
+			return err
+		}
+
+		if last > 0 {
+			_, err := tx.ExecContext(ctx, "SELECT setval($1, $2, true)", name.String, last)
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "SELECT setval($1, 1, false)", name.String)
+		return err
+	}
+
+	return nil
+}
+
+// quote quotes an identifier.
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
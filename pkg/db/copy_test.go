@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+const copyTestSchema = `
+CREATE TABLE users (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  username TEXT NOT NULL UNIQUE,
+  admin BOOLEAN NOT NULL
+);
+CREATE TABLE public_keys (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  public_key TEXT NOT NULL,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE TABLE settings (
+  key TEXT NOT NULL UNIQUE,
+  value TEXT NOT NULL
+);
+`
+
+func openCopyTestDB(t *testing.T, name string) *DB {
+	t.Helper()
+	dbx, err := Open(context.TODO(), "sqlite", filepath.Join(t.TempDir(), name)+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+	if _, err := dbx.Exec(copyTestSchema); err != nil {
+		t.Fatal(err)
+	}
+	return dbx
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.TODO()
+	src := openCopyTestDB(t, "src.db")
+	dst := openCopyTestDB(t, "dst.db")
+
+	if _, err := src.Exec(`
+		INSERT INTO users (username, admin) VALUES ('admin', true), ('deleted', false), ('user1', false);
+		DELETE FROM users WHERE username = 'deleted';
+		INSERT INTO public_keys (user_id, public_key) VALUES (3, 'key1');
+		INSERT INTO settings (key, value) VALUES ('anon_access', 'read-only');
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows created by the target migrations are replaced.
+	if _, err := dst.Exec("INSERT INTO users (username, admin) VALUES ('admin', true)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := Copy(ctx, dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []TableCopy{
+		{Name: "users", Rows: 2},
+		{Name: "public_keys", Rows: 1},
+		{Name: "settings", Rows: 1},
+	}
+	if len(tables) != len(want) {
+		t.Fatalf("Copy() => %v, want %v", tables, want)
+	}
+	for i := range want {
+		if tables[i] != want[i] {
+			t.Fatalf("Copy() => %v, want %v", tables, want)
+		}
+	}
+
+	var username string
+	if err := dst.Get(&username, "SELECT u.username FROM public_keys k JOIN users u ON u.id = k.user_id WHERE k.public_key = 'key1'"); err != nil {
+		t.Fatal(err)
+	} else if username != "user1" {
+		t.Errorf("expected key of user1, got %q", username)
+	}
+
+	// The sequence skips deleted IDs like the source database.
+	res, err := dst.Exec("INSERT INTO users (username, admin) VALUES ('user2', false)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := res.LastInsertId(); id != 4 {
+		t.Errorf("expected new user ID 4, got %d", id)
+	}
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS repo_ip_rules (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  cidr TEXT NOT NULL,
+  allow BOOLEAN NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  UNIQUE (repo_id, cidr),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
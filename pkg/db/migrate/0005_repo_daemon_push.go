@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoDaemonPushName    = "repo_daemon_push"
+	repoDaemonPushVersion = 5
+)
+
+var repoDaemonPush = Migration{
+	Name:    repoDaemonPushName,
+	Version: repoDaemonPushVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoDaemonPushVersion, repoDaemonPushName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoDaemonPushVersion, repoDaemonPushName)
+	},
+}
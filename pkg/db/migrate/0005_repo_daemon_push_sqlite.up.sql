@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN daemon_push BOOLEAN NOT NULL DEFAULT false;
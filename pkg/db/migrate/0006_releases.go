@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	releasesName    = "releases"
+	releasesVersion = 6
+)
+
+var releases = Migration{
+	Name:    releasesName,
+	Version: releasesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, releasesVersion, releasesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, releasesVersion, releasesName)
+	},
+}
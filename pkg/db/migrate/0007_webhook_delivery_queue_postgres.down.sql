@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS webhook_deliveries_status_next_attempt_at_idx;
+ALTER TABLE webhook_deliveries DROP COLUMN next_attempt_at;
+ALTER TABLE webhook_deliveries DROP COLUMN attempts;
+ALTER TABLE webhook_deliveries DROP COLUMN status;
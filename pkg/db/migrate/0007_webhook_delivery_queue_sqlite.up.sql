@@ -0,0 +1,11 @@
+ALTER TABLE webhook_deliveries ADD COLUMN status TEXT NOT NULL DEFAULT 'delivered';
+ALTER TABLE webhook_deliveries ADD COLUMN attempts INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE webhook_deliveries ADD COLUMN next_attempt_at DATETIME;
+
+-- Deliveries recorded before the queue existed were attempted exactly once.
+UPDATE webhook_deliveries SET status = 'failed'
+WHERE (request_error IS NOT NULL AND request_error != '')
+  OR response_status < 200 OR response_status >= 300;
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_idx
+ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN headers_template;
+ALTER TABLE webhooks DROP COLUMN body_template;
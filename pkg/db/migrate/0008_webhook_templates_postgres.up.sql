@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN body_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN headers_template TEXT NOT NULL DEFAULT '';
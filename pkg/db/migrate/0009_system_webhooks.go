@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	systemWebhooksName    = "system_webhooks"
+	systemWebhooksVersion = 9
+)
+
+var systemWebhooks = Migration{
+	Name:    systemWebhooksName,
+	Version: systemWebhooksVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, systemWebhooksVersion, systemWebhooksName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, systemWebhooksVersion, systemWebhooksName)
+	},
+}
@@ -0,0 +1,3 @@
+DELETE FROM webhooks WHERE repo_id IS NULL;
+DROP INDEX IF EXISTS webhooks_system_url_idx;
+ALTER TABLE webhooks ALTER COLUMN repo_id SET NOT NULL;
@@ -0,0 +1,5 @@
+-- System webhooks are webhooks without a repository.
+ALTER TABLE webhooks ALTER COLUMN repo_id DROP NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhooks_system_url_idx
+ON webhooks (url) WHERE repo_id IS NULL;
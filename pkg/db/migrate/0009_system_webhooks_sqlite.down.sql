@@ -0,0 +1,81 @@
+-- Repository webhooks can't be stored without a repository, so system
+-- webhooks are deleted along with their events and deliveries. The webhooks
+-- table is then rebuilt to restore the NOT NULL constraint of repo_id, see
+-- the up migration.
+DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE repo_id IS NULL);
+DELETE FROM webhook_events WHERE webhook_id IN (SELECT id FROM webhooks WHERE repo_id IS NULL);
+DELETE FROM webhooks WHERE repo_id IS NULL;
+DROP INDEX IF EXISTS webhooks_system_url_idx;
+
+CREATE TABLE webhook_events_old AS SELECT * FROM webhook_events;
+CREATE TABLE webhook_deliveries_old AS SELECT * FROM webhook_deliveries;
+DROP TABLE webhook_events;
+DROP TABLE webhook_deliveries;
+
+CREATE TABLE webhooks_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  repo_id INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  content_type INTEGER NOT NULL,
+  active BOOLEAN NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL,
+  body_template TEXT NOT NULL DEFAULT '',
+  headers_template TEXT NOT NULL DEFAULT '',
+  UNIQUE (repo_id, url),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+INSERT INTO webhooks_new (id, repo_id, url, secret, content_type, active, created_at, updated_at, body_template, headers_template)
+SELECT id, repo_id, url, secret, content_type, active, created_at, updated_at, body_template, headers_template FROM webhooks;
+DROP TABLE webhooks;
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE TABLE webhook_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  webhook_id INTEGER NOT NULL,
+  event INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (webhook_id, event),
+  CONSTRAINT webhook_id_fk
+  FOREIGN KEY(webhook_id) REFERENCES webhooks(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+INSERT INTO webhook_events (id, webhook_id, event, created_at)
+SELECT id, webhook_id, event, created_at FROM webhook_events_old;
+DROP TABLE webhook_events_old;
+
+CREATE TABLE webhook_deliveries (
+  id TEXT PRIMARY KEY,
+  webhook_id INTEGER NOT NULL,
+  event INTEGER NOT NULL,
+  request_url TEXT NOT NULL,
+  request_method TEXT NOT NULL,
+  request_error TEXT,
+  request_headers TEXT NOT NULL,
+  request_body TEXT NOT NULL,
+  response_status INTEGER NOT NULL,
+  response_headers TEXT NOT NULL,
+  response_body TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  status TEXT NOT NULL DEFAULT 'delivered',
+  attempts INTEGER NOT NULL DEFAULT 1,
+  next_attempt_at DATETIME,
+  CONSTRAINT webhook_id_fk
+  FOREIGN KEY(webhook_id) REFERENCES webhooks(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_error, request_headers, request_body, response_status, response_headers, response_body, created_at, status, attempts, next_attempt_at)
+SELECT id, webhook_id, event, request_url, request_method, request_error, request_headers, request_body, response_status, response_headers, response_body, created_at, status, attempts, next_attempt_at FROM webhook_deliveries_old;
+DROP TABLE webhook_deliveries_old;
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_idx
+ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	gateRulesName    = "gate_rules"
+	gateRulesVersion = 11
+)
+
+var gateRules = Migration{
+	Name:    gateRulesName,
+	Version: gateRulesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, gateRulesVersion, gateRulesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, gateRulesVersion, gateRulesName)
+	},
+}
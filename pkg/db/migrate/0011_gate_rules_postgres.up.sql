@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS repo_gate_rules (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  branch TEXT NOT NULL,
+  url TEXT NOT NULL DEFAULT '',
+  command TEXT NOT NULL DEFAULT '',
+  secret TEXT NOT NULL DEFAULT '',
+  timeout INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
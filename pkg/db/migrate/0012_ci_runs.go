@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	ciRunsName    = "ci_runs"
+	ciRunsVersion = 12
+)
+
+var ciRuns = Migration{
+	Name:    ciRunsName,
+	Version: ciRunsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, ciRunsVersion, ciRunsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, ciRunsVersion, ciRunsName)
+	},
+}
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoTrashName    = "repo_trash"
+	repoTrashVersion = 14
+)
+
+var repoTrash = Migration{
+	Name:    repoTrashName,
+	Version: repoTrashVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoTrashVersion, repoTrashName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoTrashVersion, repoTrashName)
+	},
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS repo_trash (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  user_id INTEGER,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE SET NULL
+  ON UPDATE CASCADE
+);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoTemplateName    = "repo_template"
+	repoTemplateVersion = 15
+)
+
+var repoTemplate = Migration{
+	Name:    repoTemplateName,
+	Version: repoTemplateVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoTemplateVersion, repoTemplateName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoTemplateVersion, repoTemplateName)
+	},
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS repo_topics (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  topic TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (repo_id, topic),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
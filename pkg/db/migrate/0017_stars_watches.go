@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	starsWatchesName    = "stars_watches"
+	starsWatchesVersion = 17
+)
+
+var starsWatches = Migration{
+	Name:    starsWatchesName,
+	Version: starsWatchesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, starsWatchesVersion, starsWatchesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, starsWatchesVersion, starsWatchesName)
+	},
+}
@@ -95,6 +95,26 @@ func Migrate(ctx context.Context, dbx *db.DB) error {
 	})
 }
 
+// Version returns the version of the latest migration run on the database,
+// or zero if no migrations were run.
+func Version(ctx context.Context, dbx *db.DB) (int64, error) {
+	var version int64
+	err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if !hasTable(tx, "migrations") {
+			return nil
+		}
+
+		return tx.GetContext(ctx, &version, "SELECT COALESCE(MAX(version), 0) FROM migrations")
+	})
+
+	return version, err
+}
+
+// LatestVersion returns the version of the latest migration.
+func LatestVersion() int64 {
+	return migrations[len(migrations)-1].Version
+}
+
 // Rollback rolls back a migration.
 func Rollback(ctx context.Context, dbx *db.DB) error {
 	logger := log.FromContext(ctx).WithPrefix("migrate")
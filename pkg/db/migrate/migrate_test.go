@@ -2,9 +2,11 @@ package migrate
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/internal/test"
 )
 
@@ -20,3 +22,141 @@ func TestMigrate(t *testing.T) {
 		t.Errorf("Migrate() => %v, want nil error", err)
 	}
 }
+
+func TestMigrateSystemWebhooks(t *testing.T) {
+	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
+	// Open the database with foreign keys enabled, like the server does, so
+	// that rebuilding tables would cascade.
+	dbx, err := db.Open(ctx, "sqlite", filepath.Join(t.TempDir(), "test.db")+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+
+	// Migrate up to the version before system webhooks were introduced.
+	all := migrations
+	t.Cleanup(func() { migrations = all })
+	for i, m := range all {
+		if m.Version == systemWebhooksVersion {
+			migrations = all[:i]
+		}
+	}
+
+	if err := Migrate(ctx, dbx); err != nil {
+		t.Fatalf("Migrate() => %v, want nil error", err)
+	}
+
+	for _, q := range []string{
+		`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, updated_at)
+			VALUES ('repo1', '', '', false, false, false, 1, CURRENT_TIMESTAMP);`,
+		`INSERT INTO webhooks (repo_id, url, secret, content_type, active, updated_at)
+			VALUES (1, 'https://example.com', '', 0, true, CURRENT_TIMESTAMP);`,
+		`INSERT INTO webhook_events (webhook_id, event) VALUES (1, 4);`,
+		`INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_headers, request_body, response_status, response_headers, response_body)
+			VALUES ('a', 1, 4, 'https://example.com', 'POST', '', '', 200, '', '');`,
+	} {
+		if _, err := dbx.ExecContext(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrations = all
+	if err := Migrate(ctx, dbx); err != nil {
+		t.Fatalf("Migrate() => %v, want nil error", err)
+	}
+
+	for table, want := range map[string]int{
+		"webhooks":           1,
+		"webhook_events":     1,
+		"webhook_deliveries": 1,
+	} {
+		var n int
+		if err := dbx.GetContext(ctx, &n, "SELECT COUNT(*) FROM "+table); err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Errorf("%s has %d rows, want %d", table, n, want)
+		}
+	}
+
+	if _, err := dbx.ExecContext(ctx, `INSERT INTO webhooks (url, secret, content_type, active, updated_at)
+		VALUES ('https://example.com', '', 0, true, CURRENT_TIMESTAMP);`); err != nil {
+		t.Errorf("failed to create system webhook: %v", err)
+	}
+
+	// Deleting the repository still cascades to its webhooks.
+	if _, err := dbx.ExecContext(ctx, `DELETE FROM repos WHERE id = 1;`); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err := dbx.GetContext(ctx, &n, "SELECT COUNT(*) FROM webhook_deliveries"); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("webhook_deliveries has %d rows, want 0", n)
+	}
+}
+
+func TestRollbackSystemWebhooks(t *testing.T) {
+	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
+	dbx, err := db.Open(ctx, "sqlite", filepath.Join(t.TempDir(), "test.db")+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+
+	// Migrate up to the version that introduced system webhooks.
+	all := migrations
+	t.Cleanup(func() { migrations = all })
+	for i, m := range all {
+		if m.Version == systemWebhooksVersion {
+			migrations = all[:i+1]
+		}
+	}
+
+	if err := Migrate(ctx, dbx); err != nil {
+		t.Fatalf("Migrate() => %v, want nil error", err)
+	}
+
+	for _, q := range []string{
+		`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, updated_at)
+			VALUES ('repo1', '', '', false, false, false, 1, CURRENT_TIMESTAMP);`,
+		`INSERT INTO webhooks (repo_id, url, secret, content_type, active, updated_at)
+			VALUES (1, 'https://example.com', '', 0, true, CURRENT_TIMESTAMP);`,
+		`INSERT INTO webhooks (url, secret, content_type, active, updated_at)
+			VALUES ('https://example.com', '', 0, true, CURRENT_TIMESTAMP);`,
+		`INSERT INTO webhook_events (webhook_id, event) VALUES (1, 4), (2, 4);`,
+		`INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_headers, request_body, response_status, response_headers, response_body)
+			VALUES ('a', 1, 4, 'https://example.com', 'POST', '', '', 200, '', ''),
+			('b', 2, 4, 'https://example.com', 'POST', '', '', 200, '', '');`,
+	} {
+		if _, err := dbx.ExecContext(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Rollback(ctx, dbx); err != nil {
+		t.Fatalf("Rollback() => %v, want nil error", err)
+	}
+
+	// System webhooks are deleted, repository webhooks are kept.
+	for table, want := range map[string]int{
+		"webhooks":           1,
+		"webhook_events":     1,
+		"webhook_deliveries": 1,
+	} {
+		var n int
+		if err := dbx.GetContext(ctx, &n, "SELECT COUNT(*) FROM "+table); err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Errorf("%s has %d rows, want %d", table, n, want)
+		}
+	}
+
+	if _, err := dbx.ExecContext(ctx, `INSERT INTO webhooks (url, secret, content_type, active, updated_at)
+		VALUES ('https://example.org', '', 0, true, CURRENT_TIMESTAMP);`); err == nil {
+		t.Error("expected webhooks without a repository to be rejected")
+	}
+}
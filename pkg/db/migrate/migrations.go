@@ -18,6 +18,20 @@ var migrations = []Migration{
 	createTables,
 	webhooks,
 	migrateLfsObjects,
+	ipRules,
+	repoDaemonPush,
+	releases,
+	webhookDeliveryQueue,
+	webhookTemplates,
+	systemWebhooks,
+	commitStatuses,
+	gateRules,
+	ciRuns,
+	repoArchived,
+	repoTrash,
+	repoTemplate,
+	repoTopics,
+	starsWatches,
 }
 
 func execMigration(ctx context.Context, tx *db.Tx, version int, name string, down bool) error {
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CIRun is a run of the built-in CI pipeline of a repository commit.
+type CIRun struct {
+	ID         int64         `db:"id"`
+	RepoID     int64         `db:"repo_id"`
+	UserID     sql.NullInt64 `db:"user_id"`
+	CommitSHA  string        `db:"commit_sha"`
+	Ref        string        `db:"ref"`
+	Status     string        `db:"status"`
+	CreatedAt  time.Time     `db:"created_at"`
+	StartedAt  sql.NullTime  `db:"started_at"`
+	FinishedAt sql.NullTime  `db:"finished_at"`
+	UpdatedAt  time.Time     `db:"updated_at"`
+}
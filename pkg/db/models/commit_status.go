@@ -0,0 +1,21 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CommitStatus is the status of a commit reported by an external system, such
+// as a CI service, for a context.
+type CommitStatus struct {
+	ID          int64         `db:"id"`
+	RepoID      int64         `db:"repo_id"`
+	UserID      sql.NullInt64 `db:"user_id"`
+	CommitSHA   string        `db:"commit_sha"`
+	Context     string        `db:"context"`
+	State       string        `db:"state"`
+	TargetURL   string        `db:"target_url"`
+	Description string        `db:"description"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+}
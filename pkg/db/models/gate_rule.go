@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// GateRule is a repository gate rule. Updates to matching branches must be
+// approved by the rule HTTP endpoint or local command.
+type GateRule struct {
+	ID      int64  `db:"id"`
+	RepoID  int64  `db:"repo_id"`
+	Branch  string `db:"branch"`
+	URL     string `db:"url"`
+	Command string `db:"command"`
+	Secret  string `db:"secret"`
+	// Timeout is the gate timeout in seconds.
+	Timeout   int64     `db:"timeout"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
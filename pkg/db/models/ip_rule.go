@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// IPRule is a repository IP access rule.
+type IPRule struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	CIDR      string    `db:"cidr"`
+	Allow     bool      `db:"allow"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
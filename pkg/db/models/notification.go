@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Notification is a user notification about an event of a watched
+// repository.
+type Notification struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	RepoID    int64     `db:"repo_id"`
+	Event     int       `db:"event"`
+	Message   string    `db:"message"`
+	Read      bool      `db:"read"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Release is a repository release tied to a tag.
+type Release struct {
+	ID        int64         `db:"id"`
+	RepoID    int64         `db:"repo_id"`
+	UserID    sql.NullInt64 `db:"user_id"`
+	Tag       string        `db:"tag"`
+	Title     string        `db:"title"`
+	Notes     string        `db:"notes"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+}
+
+// ReleaseAsset is a file attached to a release.
+type ReleaseAsset struct {
+	ID        int64     `db:"id"`
+	ReleaseID int64     `db:"release_id"`
+	Name      string    `db:"name"`
+	Size      int64     `db:"size"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -14,6 +14,9 @@ type Repo struct {
 	Private     bool          `db:"private"`
 	Mirror      bool          `db:"mirror"`
 	Hidden      bool          `db:"hidden"`
+	DaemonPush  bool          `db:"daemon_push"`
+	Archived    bool          `db:"archived"`
+	Template    bool          `db:"template"`
 	UserID      sql.NullInt64 `db:"user_id"`
 	CreatedAt   time.Time     `db:"created_at"`
 	UpdatedAt   time.Time     `db:"updated_at"`
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RepoTopic is a topic of a repository.
+type RepoTopic struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	Topic     string    `db:"topic"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TrashedRepo is a deleted repository kept in the trash until it's restored
+// or purged.
+type TrashedRepo struct {
+	ID        int64         `db:"id"`
+	RepoID    int64         `db:"repo_id"`
+	Name      string        `db:"name"`
+	UserID    sql.NullInt64 `db:"user_id"`
+	CreatedAt time.Time     `db:"created_at"`
+}
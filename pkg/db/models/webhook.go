@@ -9,14 +9,19 @@ import (
 
 // Webhook is a repository webhook.
 type Webhook struct {
-	ID          int64     `db:"id"`
-	RepoID      int64     `db:"repo_id"`
-	URL         string    `db:"url"`
-	Secret      string    `db:"secret"`
-	ContentType int       `db:"content_type"`
-	Active      bool      `db:"active"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int64         `db:"id"`
+	RepoID      sql.NullInt64 `db:"repo_id"`
+	URL         string        `db:"url"`
+	Secret      string        `db:"secret"`
+	ContentType int           `db:"content_type"`
+	Active      bool          `db:"active"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+
+	// BodyTemplate is the template of the request body.
+	BodyTemplate string `db:"body_template"`
+	// HeadersTemplate is the template of the extra request headers.
+	HeadersTemplate string `db:"headers_template"`
 }
 
 // WebhookEvent is a webhook event.
@@ -40,5 +45,8 @@ type WebhookDelivery struct {
 	ResponseStatus  int            `db:"response_status"`
 	ResponseHeaders string         `db:"response_headers"`
 	ResponseBody    string         `db:"response_body"`
+	Status          string         `db:"status"`
+	Attempts        int            `db:"attempts"`
+	NextAttemptAt   sql.NullTime   `db:"next_attempt_at"`
 	CreatedAt       time.Time      `db:"created_at"`
 }
@@ -0,0 +1,230 @@
+package gate
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/version"
+	"github.com/gobwas/glob"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTimeout is the timeout of gates that don't specify one.
+	DefaultTimeout = 30 * time.Second
+
+	// maxMessageSize is the maximum size of a denial message.
+	maxMessageSize = 4 << 10
+)
+
+var (
+	// ErrInvalidGate is returned when a gate doesn't have exactly one of a URL
+	// or a command.
+	ErrInvalidGate = errors.New("gate must have either a URL or a command")
+	// ErrInvalidPattern is returned when a branch pattern is invalid.
+	ErrInvalidPattern = errors.New("invalid branch pattern")
+	// ErrInvalidURL is returned when a gate URL is not an HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid gate URL")
+	// ErrInvalidTimeout is returned when a gate timeout is invalid.
+	ErrInvalidTimeout = errors.New("gate timeout must be at least one second")
+)
+
+// DeniedError is returned when a gate denies an update.
+type DeniedError struct {
+	// Message is the denial message of the gate.
+	Message string
+}
+
+// Error implements error.
+func (e *DeniedError) Error() string {
+	if e.Message == "" {
+		return "update denied"
+	}
+	return e.Message
+}
+
+// Validate validates the branch pattern, target, and timeout of a gate.
+func Validate(pattern string, rawURL string, command string, timeout time.Duration) error {
+	if (rawURL == "") == (command == "") {
+		return ErrInvalidGate
+	}
+
+	if pattern == "" {
+		return ErrInvalidPattern
+	}
+
+	if _, err := glob.Compile(pattern, '/'); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+
+	if rawURL != "" {
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidURL
+		}
+	}
+
+	if timeout != 0 && timeout < time.Second {
+		return ErrInvalidTimeout
+	}
+
+	return nil
+}
+
+// Match returns whether a branch matches a gate branch pattern. Patterns are
+// globs where `*` doesn't match `/` and `**` matches anything.
+func Match(pattern string, branch string) bool {
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		return false
+	}
+
+	return g.Match(branch)
+}
+
+// Request is the request body sent to gates.
+type Request struct {
+	// Repository is the repository name.
+	Repository string `json:"repository"`
+	// Ref is the updated reference name.
+	Ref string `json:"ref"`
+	// Branch is the updated branch name.
+	Branch string `json:"branch"`
+	// Before is the branch SHA before the update.
+	Before string `json:"before"`
+	// After is the branch SHA after the update. It is a zero hash when the
+	// branch is deleted.
+	After string `json:"after"`
+	// Pusher is the username of the pusher, empty for anonymous pushes.
+	Pusher string `json:"pusher"`
+}
+
+// Check asks a gate to approve an update.
+//
+// HTTP gates receive a signed JSON request and approve the update with a 2xx
+// response. Command gates are run with the ref name, old SHA, and new SHA as
+// arguments, like the git update hook, and the JSON request on stdin. They
+// approve the update by exiting with a zero status. The response body or the
+// command output is the denial message.
+//
+// It returns a DeniedError when the gate denies the update, and other errors
+// when the gate couldn't be checked, including timeouts.
+func Check(ctx context.Context, rule models.GateRule, req Request) error {
+	timeout := time.Duration(rule.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case rule.URL != "":
+		return checkURL(ctx, rule, body)
+	case rule.Command != "":
+		return checkCommand(ctx, rule, req, body)
+	default:
+		return ErrInvalidGate
+	}
+}
+
+func checkURL(ctx context.Context, rule models.GateRule, body []byte) error {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, rule.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("User-Agent", "SoftServe/"+version.Version)
+	r.Header.Set("X-SoftServe-Event", "gate")
+	r.Header.Set("X-SoftServe-Delivery", id.String())
+	if rule.Secret != "" {
+		r.Header.Set("X-SoftServe-Signature", "sha256="+Sign(rule.Secret, body))
+	}
+
+	res, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+	msg, err := io.ReadAll(io.LimitReader(res.Body, maxMessageSize))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	denied := &DeniedError{Message: strings.TrimSpace(string(msg))}
+	if denied.Message == "" {
+		denied.Message = res.Status
+	}
+
+	return denied
+}
+
+func checkCommand(ctx context.Context, rule models.GateRule, req Request, body []byte) error {
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, rule.Command, req.Ref, req.Before, req.After)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &exitErr):
+		msg := out.String()
+		if len(msg) > maxMessageSize {
+			msg = msg[:maxMessageSize]
+		}
+
+		denied := &DeniedError{Message: strings.TrimSpace(msg)}
+		if denied.Message == "" {
+			denied.Message = fmt.Sprintf("exit status %d", exitErr.ExitCode())
+		}
+
+		return denied
+	default:
+		return err
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of a gate request body.
+func Sign(secret string, body []byte) string {
+	sig := hmac.New(sha256.New, []byte(secret))
+	sig.Write(body) // nolint: errcheck
+	return hex.EncodeToString(sig.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+var testRequest = Request{
+	Repository: "repo1",
+	Ref:        "refs/heads/main",
+	Branch:     "main",
+	Before:     "0000000000000000000000000000000000000000",
+	After:      "0123456789abcdef0123456789abcdef01234567",
+	Pusher:     "alice",
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		branch  string
+		want    bool
+	}{
+		{"main", "main", true},
+		{"main", "maint", false},
+		{"release/*", "release/1.0", true},
+		{"release/*", "release/1.0/fix", false},
+		{"release/**", "release/1.0/fix", true},
+		{"*", "feature/foo", false},
+		{"**", "feature/foo", true},
+	}
+
+	for _, c := range cases {
+		if got := Match(c.pattern, c.branch); got != c.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", c.pattern, c.branch, got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		pattern string
+		url     string
+		command string
+		timeout time.Duration
+		err     error
+	}{
+		{"url", "main", "https://example.com/gate", "", 0, nil},
+		{"command", "main", "", "/bin/true", time.Minute, nil},
+		{"none", "main", "", "", 0, ErrInvalidGate},
+		{"both", "main", "https://example.com/gate", "/bin/true", 0, ErrInvalidGate},
+		{"empty pattern", "", "https://example.com/gate", "", 0, ErrInvalidPattern},
+		{"bad pattern", "[main", "https://example.com/gate", "", 0, ErrInvalidPattern},
+		{"bad url", "main", "ftp://example.com/gate", "", 0, ErrInvalidURL},
+		{"short timeout", "main", "https://example.com/gate", "", time.Millisecond, ErrInvalidTimeout},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := Validate(c.pattern, c.url, c.command, c.timeout); !errors.Is(err, c.err) {
+				t.Errorf("Validate() = %v, want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := r.Header.Get("X-SoftServe-Signature"), "sha256="+Sign("secret", body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatal(err)
+		}
+
+		switch req.Pusher {
+		case "alice":
+			w.WriteHeader(http.StatusNoContent)
+		case "bob":
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "bob can't push to main\n") // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	rule := models.GateRule{ID: 1, Branch: "main", URL: srv.URL, Secret: "secret", Timeout: 5}
+	if err := Check(context.Background(), rule, testRequest); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+
+	req := testRequest
+	req.Pusher = "bob"
+	var denied *DeniedError
+	if err := Check(context.Background(), rule, req); !errors.As(err, &denied) || denied.Message != "bob can't push to main" {
+		t.Errorf("Check() = %v, want denial", err)
+	}
+
+	req.Pusher = ""
+	if err := Check(context.Background(), rule, req); !errors.As(err, &denied) || denied.Message != "403 Forbidden" {
+		t.Errorf("Check() = %v, want 403 denial", err)
+	}
+}
+
+func TestCheckURLTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	rule := models.GateRule{ID: 1, Branch: "main", URL: srv.URL, Timeout: 1}
+	if err := Check(context.Background(), rule, testRequest); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Check() = %v, want deadline exceeded", err)
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("gate commands are shell scripts")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "gate")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+grep -q '"pusher":"alice"' && exit 0
+echo "denied $1 $3"
+exit 1
+`), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := models.GateRule{ID: 1, Branch: "main", Command: script, Timeout: 5}
+	if err := Check(context.Background(), rule, testRequest); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+
+	req := testRequest
+	req.Pusher = "bob"
+	var denied *DeniedError
+	want := "denied refs/heads/main " + req.After
+	if err := Check(context.Background(), rule, req); !errors.As(err, &denied) || denied.Message != want {
+		t.Errorf("Check() = %v, want %q", err, want)
+	}
+}
@@ -6,12 +6,18 @@ var (
 	// ErrNotAuthed represents unauthorized access.
 	ErrNotAuthed = errors.New("you are not authorized to do this")
 
+	// ErrAddrNotAllowed represents access from a denied network address.
+	ErrAddrNotAllowed = errors.New("you are not authorized to do this from your network address")
+
 	// ErrSystemMalfunction represents a general system error returned to clients.
 	ErrSystemMalfunction = errors.New("something went wrong")
 
 	// ErrInvalidRepo represents an attempt to access a non-existent repo.
 	ErrInvalidRepo = errors.New("invalid repo")
 
+	// ErrArchived represents an attempt to write to an archived repo.
+	ErrArchived = errors.New("repository is archived")
+
 	// ErrInvalidRequest represents an invalid request.
 	ErrInvalidRequest = errors.New("invalid request")
 
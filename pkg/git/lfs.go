@@ -216,6 +216,10 @@ func (t *lfsTransfer) LockBackend(args transfer.Args) transfer.LockBackend {
 
 // Create implements transfer.LockBackend.
 func (l *lfsLockBackend) Create(path string, refname string) (transfer.Lock, error) {
+	if l.repo.IsArchived() {
+		return nil, fmt.Errorf("%w: %s", transfer.ErrForbidden, ErrArchived)
+	}
+
 	var lock LFSLock
 	if err := l.dbx.TransactionContext(l.ctx, func(tx *db.Tx) error {
 		if err := l.store.CreateLFSLockForUser(l.ctx, tx, l.repo.ID(), l.user.ID(), path, refname); err != nil {
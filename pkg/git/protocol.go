@@ -0,0 +1,28 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ProtocolEnv is the environment variable used to pass Git protocol
+// parameters, like the protocol version, down to the git binary.
+const ProtocolEnv = "GIT_PROTOCOL"
+
+// ProtocolVersion returns the highest protocol version requested in the
+// given colon separated Git protocol parameters, e.g. "version=2".
+// It returns 0 if no version is requested.
+//
+// See https://git-scm.com/docs/protocol-v2
+func ProtocolVersion(params string) int {
+	var version int
+	for _, p := range strings.Split(params, ":") {
+		if v, ok := strings.CutPrefix(p, "version="); ok {
+			if n, _ := strconv.Atoi(v); n > version {
+				version = n
+			}
+		}
+	}
+
+	return version
+}
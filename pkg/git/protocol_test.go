@@ -0,0 +1,23 @@
+package git
+
+import "testing"
+
+func TestProtocolVersion(t *testing.T) {
+	cases := []struct {
+		in  string
+		out int
+	}{
+		{"", 0},
+		{"version=1", 1},
+		{"version=2", 2},
+		{"foo=bar:version=2", 2},
+		{"version=1:version=2", 2},
+		{"version=foo", 0},
+	}
+
+	for _, c := range cases {
+		if v := ProtocolVersion(c.in); v != c.out {
+			t.Errorf("ProtocolVersion(%q) => %d, want %d", c.in, v, c.out)
+		}
+	}
+}
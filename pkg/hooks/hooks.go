@@ -13,9 +13,11 @@ type HookArg struct {
 }
 
 // Hooks provides an interface for git server-side hooks.
+//
+// Update rejects the ref update when it returns an error.
 type Hooks interface {
 	PreReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg)
-	Update(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, arg HookArg)
+	Update(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, arg HookArg) error
 	PostReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg)
 	PostUpdate(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args ...string)
 }
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("archive-cache", &archiveCache{})
+}
+
+type archiveCache struct{}
+
+// Spec derives the spec used for pruning cached archives and implements
+// Runner.
+func (a *archiveCache) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.ArchiveCache != "" {
+		return cfg.Jobs.ArchiveCache
+	}
+	return "@every 1h"
+}
+
+// Func removes expired cached archives and implements Runner.
+func (a *archiveCache) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.archive-cache")
+	b := backend.FromContext(ctx)
+	return func() {
+		n, err := b.PruneArchiveCache(ctx)
+		if err != nil {
+			logger.Error("error pruning cached archives", "err", err)
+		}
+
+		if n > 0 {
+			logger.Info("pruned cached archives", "count", n)
+		}
+	}
+}
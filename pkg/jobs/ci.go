@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"strconv"
+	gosync "sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/sync"
+)
+
+func init() {
+	Register("ci", &ciRuns{})
+}
+
+type ciRuns struct {
+	// running prevents overlapping runs when a batch takes longer than the
+	// job interval.
+	running gosync.Mutex
+}
+
+// Spec derives the spec used for CI runs and implements Runner.
+func (c *ciRuns) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.CI != "" {
+		return cfg.Jobs.CI
+	}
+	return "@every 5s"
+}
+
+// Func runs queued CI runs and implements Runner.
+func (c *ciRuns) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.ci")
+	b := backend.FromContext(ctx)
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	return func() {
+		if !cfg.CI.Enabled {
+			return
+		}
+
+		if !c.running.TryLock() {
+			logger.Debug("ci runs are still running, skipping")
+			return
+		}
+		defer c.running.Unlock()
+
+		runs, err := datastore.GetQueuedCIRuns(ctx, dbx, cfg.CI.Workers)
+		if err != nil {
+			logger.Error("error getting queued ci runs", "err", err)
+			return
+		}
+
+		if len(runs) == 0 {
+			return
+		}
+
+		wq := sync.NewWorkPool(ctx, cfg.CI.Workers,
+			sync.WithWorkPoolLogger(logger.Errorf),
+		)
+
+		logger.Debug("running ci runs", "count", len(runs))
+		for _, r := range runs {
+			r := r
+			wq.Add(strconv.FormatInt(r.ID, 10), func() {
+				if err := b.RunCIRun(ctx, r); err != nil {
+					logger.Error("error running ci run", "run", r.ID, "repo", r.RepoID, "err", err)
+				}
+			})
+		}
+
+		wq.Run()
+	}
+}
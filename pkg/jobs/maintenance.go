@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	gosync "sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sync"
+)
+
+func init() {
+	Register("maintenance", &maintenance{})
+}
+
+type maintenance struct {
+	// running prevents overlapping runs when maintenance takes longer than
+	// the job interval.
+	running gosync.Mutex
+}
+
+// Spec derives the spec used for repository maintenance and implements
+// Runner.
+func (m *maintenance) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Maintenance != "" {
+		return cfg.Jobs.Maintenance
+	}
+	return "@every 1h"
+}
+
+// Func maintains the repositories that need it and implements Runner.
+func (m *maintenance) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.maintenance")
+	b := backend.FromContext(ctx)
+	return func() {
+		if !m.running.TryLock() {
+			logger.Debug("maintenance is still running, skipping")
+			return
+		}
+		defer m.running.Unlock()
+
+		repos, err := b.Repositories(ctx)
+		if err != nil {
+			logger.Error("error getting repositories", "err", err)
+			return
+		}
+
+		wq := sync.NewWorkPool(ctx, cfg.Maintenance.Workers,
+			sync.WithWorkPoolLogger(logger.Errorf),
+		)
+
+		for _, r := range repos {
+			name := r.Name()
+			wq.Add(name, func() {
+				if _, err := b.MaintainRepository(ctx, name, false); err != nil && !errors.Is(err, proto.ErrMaintenanceRunning) {
+					logger.Error("error maintaining repository", "repo", name, "err", err)
+				}
+			})
+		}
+
+		wq.Run()
+	}
+}
@@ -84,6 +84,10 @@ func (m mirrorPull) Func(ctx context.Context) func() {
 						}
 					}
 
+					if err := b.IndexRepository(ctx, name); err != nil {
+						logger.Error("error indexing repository", "repo", name, "err", err)
+					}
+
 					if cfg.LFS.Enabled {
 						rcfg, err := r.Config()
 						if err != nil {
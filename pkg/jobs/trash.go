@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("trash", &trash{})
+}
+
+type trash struct{}
+
+// Spec derives the spec used for purging the trash and implements Runner.
+func (t *trash) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Trash != "" {
+		return cfg.Jobs.Trash
+	}
+	return "@every 1h"
+}
+
+// Func purges expired trashed repositories and implements Runner.
+func (t *trash) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.trash")
+	b := backend.FromContext(ctx)
+	return func() {
+		n, err := b.PurgeExpiredRepositories(ctx)
+		if err != nil {
+			logger.Error("error purging trashed repositories", "err", err)
+		}
+
+		if n > 0 {
+			logger.Info("purged trashed repositories", "count", n)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	gosync "sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/sync"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+func init() {
+	Register("webhook-deliveries", &webhookDeliveries{})
+}
+
+// webhookDeliveriesBatch is the maximum number of deliveries sent per run.
+const webhookDeliveriesBatch = 100
+
+type webhookDeliveries struct {
+	// running prevents overlapping runs when a batch takes longer than the
+	// job interval.
+	running gosync.Mutex
+}
+
+// Spec derives the spec used for webhook deliveries and implements Runner.
+func (w *webhookDeliveries) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.WebhookDeliveries != "" {
+		return cfg.Jobs.WebhookDeliveries
+	}
+	return "@every 10s"
+}
+
+// Func sends pending webhook deliveries and implements Runner.
+func (w *webhookDeliveries) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.webhooks")
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	return func() {
+		if !w.running.TryLock() {
+			logger.Debug("webhook deliveries are still running, skipping")
+			return
+		}
+		defer w.running.Unlock()
+
+		deliveries, err := datastore.GetDueWebhookDeliveries(ctx, dbx, time.Now(), webhookDeliveriesBatch)
+		if err != nil {
+			logger.Error("error getting pending webhook deliveries", "err", err)
+			return
+		}
+
+		if len(deliveries) == 0 {
+			return
+		}
+
+		wq := sync.NewWorkPool(ctx, cfg.Webhook.Workers,
+			sync.WithWorkPoolLogger(logger.Errorf),
+		)
+
+		logger.Debug("sending webhook deliveries", "count", len(deliveries))
+		for _, d := range deliveries {
+			d := d
+			wq.Add(d.ID.String(), func() {
+				claimed, err := webhook.ClaimWebhookDelivery(ctx, d)
+				if err != nil {
+					logger.Error("error claiming webhook delivery", "delivery", d.ID, "webhook", d.WebhookID, "err", err)
+					return
+				}
+				if !claimed {
+					logger.Debug("webhook delivery already claimed, skipping", "delivery", d.ID)
+					return
+				}
+
+				if err := webhook.DeliverWebhook(ctx, d, cfg.Webhook.MaxAttempts); err != nil {
+					logger.Error("error delivering webhook", "delivery", d.ID, "webhook", d.WebhookID, "err", err)
+				}
+			})
+		}
+
+		wq.Run()
+	}
+}
@@ -0,0 +1,49 @@
+package proto
+
+import "strings"
+
+// CommitStatusState is the state of a commit status.
+type CommitStatusState string
+
+const (
+	// CommitStatusPending is a status of a running check.
+	CommitStatusPending CommitStatusState = "pending"
+	// CommitStatusSuccess is a status of a successful check.
+	CommitStatusSuccess CommitStatusState = "success"
+	// CommitStatusFailure is a status of a failed check.
+	CommitStatusFailure CommitStatusState = "failure"
+	// CommitStatusError is a status of a check that couldn't run.
+	CommitStatusError CommitStatusState = "error"
+)
+
+// ParseCommitStatusState parses a commit status state.
+func ParseCommitStatusState(s string) (CommitStatusState, error) {
+	switch st := CommitStatusState(strings.ToLower(strings.TrimSpace(s))); st {
+	case CommitStatusPending, CommitStatusSuccess, CommitStatusFailure, CommitStatusError:
+		return st, nil
+	default:
+		return "", ErrInvalidCommitStatusState
+	}
+}
+
+// CombinedCommitStatusState returns the combined state of the statuses of a
+// commit. It is failure if any status failed or errored, pending if any
+// status is pending, and success if all statuses succeeded. Commits without
+// statuses have an empty state.
+func CombinedCommitStatusState(states ...CommitStatusState) CommitStatusState {
+	var combined CommitStatusState
+	for _, st := range states {
+		switch st {
+		case CommitStatusFailure, CommitStatusError:
+			return CommitStatusFailure
+		case CommitStatusPending:
+			combined = CommitStatusPending
+		case CommitStatusSuccess:
+			if combined == "" {
+				combined = CommitStatusSuccess
+			}
+		}
+	}
+
+	return combined
+}
@@ -0,0 +1,51 @@
+package proto
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCommitStatusState(t *testing.T) {
+	cases := []struct {
+		in   string
+		want CommitStatusState
+		err  error
+	}{
+		{"pending", CommitStatusPending, nil},
+		{" Success ", CommitStatusSuccess, nil},
+		{"FAILURE", CommitStatusFailure, nil},
+		{"error", CommitStatusError, nil},
+		{"done", "", ErrInvalidCommitStatusState},
+		{"", "", ErrInvalidCommitStatusState},
+	}
+
+	for _, c := range cases {
+		got, err := ParseCommitStatusState(c.in)
+		if !errors.Is(err, c.err) {
+			t.Errorf("ParseCommitStatusState(%q) error = %v, want %v", c.in, err, c.err)
+		}
+		if got != c.want {
+			t.Errorf("ParseCommitStatusState(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestCombinedCommitStatusState(t *testing.T) {
+	cases := []struct {
+		states []CommitStatusState
+		want   CommitStatusState
+	}{
+		{nil, ""},
+		{[]CommitStatusState{CommitStatusSuccess, CommitStatusSuccess}, CommitStatusSuccess},
+		{[]CommitStatusState{CommitStatusSuccess, CommitStatusPending}, CommitStatusPending},
+		{[]CommitStatusState{CommitStatusPending, CommitStatusSuccess}, CommitStatusPending},
+		{[]CommitStatusState{CommitStatusPending, CommitStatusError}, CommitStatusFailure},
+		{[]CommitStatusState{CommitStatusFailure, CommitStatusSuccess}, CommitStatusFailure},
+	}
+
+	for _, c := range cases {
+		if got := CombinedCommitStatusState(c.states...); got != c.want {
+			t.Errorf("CombinedCommitStatusState(%v) = %q, want %q", c.states, got, c.want)
+		}
+	}
+}
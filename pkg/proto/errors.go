@@ -23,4 +23,31 @@ var (
 	ErrCollaboratorNotFound = errors.New("collaborator not found")
 	// ErrCollaboratorExist is returned when a collaborator already exists.
 	ErrCollaboratorExist = errors.New("collaborator already exists")
+	// ErrIPRuleNotFound is returned when an IP rule is not found.
+	ErrIPRuleNotFound = errors.New("ip rule not found")
+	// ErrIPRuleExist is returned when an IP rule already exists.
+	ErrIPRuleExist = errors.New("ip rule already exists")
+	// ErrReleaseNotFound is returned when a release is not found.
+	ErrReleaseNotFound = errors.New("release not found")
+	// ErrReleaseExist is returned when a release already exists.
+	ErrReleaseExist = errors.New("release already exists")
+	// ErrReleaseAssetNotFound is returned when a release asset is not found.
+	ErrReleaseAssetNotFound = errors.New("release asset not found")
+	// ErrInvalidCommitStatusState is returned when a commit status state is invalid.
+	ErrInvalidCommitStatusState = errors.New("invalid commit status state")
+	// ErrGateRuleNotFound is returned when a gate rule is not found.
+	ErrGateRuleNotFound = errors.New("gate rule not found")
+	// ErrCIRunNotFound is returned when a CI run is not found.
+	ErrCIRunNotFound = errors.New("ci run not found")
+	// ErrCIDisabled is returned when the built-in CI runner is disabled.
+	ErrCIDisabled = errors.New("ci is disabled")
+	// ErrMaintenanceRunning is returned when a repository is already being
+	// maintained.
+	ErrMaintenanceRunning = errors.New("repository maintenance is already running")
+	// ErrTrashedRepoNotFound is returned when a trashed repository is not found.
+	ErrTrashedRepoNotFound = errors.New("trashed repository not found")
+	// ErrNotTemplate is returned when a repository is not a template.
+	ErrNotTemplate = errors.New("repository is not a template")
+	// ErrNotificationNotFound is returned when a notification is not found.
+	ErrNotificationNotFound = errors.New("notification not found")
 )
@@ -22,6 +22,12 @@ type Repository interface {
 	IsMirror() bool
 	// IsHidden returns whether the repository is hidden.
 	IsHidden() bool
+	// IsArchived returns whether the repository is archived and read-only.
+	IsArchived() bool
+	// IsTemplate returns whether the repository is a template.
+	IsTemplate() bool
+	// Topics returns the repository's topics, sorted by name.
+	Topics() []string
 	// UserID returns the ID of the user who owns the repository.
 	// It returns 0 if the repository is not owned by a user.
 	UserID() int64
@@ -43,6 +49,18 @@ type RepositoryOptions struct {
 	Hidden      bool
 	LFS         bool
 	LFSEndpoint string
+
+	// Template is the name of a template repository to initialize the new
+	// repository from. The new repository gets the template's files as a
+	// single initial commit.
+	Template string
+	// TemplateWebhooks copies the template's webhooks.
+	TemplateWebhooks bool
+	// TemplateCollabs copies the template's collaborators.
+	TemplateCollabs bool
+	// TemplateDescription copies the template's description when no
+	// description is given.
+	TemplateDescription bool
 }
 
 // RepositoryDefaultBranch returns the default branch of a repository.
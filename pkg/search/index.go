@@ -0,0 +1,274 @@
+package search
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+const (
+	// MaxFileSize is the maximum size of indexed files. Larger files are
+	// skipped.
+	MaxFileSize = 1 << 20
+
+	// binarySniffLen is the number of bytes checked for NUL bytes to detect
+	// binary files, like git does.
+	binarySniffLen = 8000
+
+	// magic starts index files, followed by the header length.
+	magic = "SOFTIDX2"
+)
+
+// ErrInvalidIndex is returned when loading a file that isn't a search index,
+// or an index written in an older format.
+var ErrInvalidIndex = errors.New("invalid search index")
+
+// File is an indexed file.
+type File struct {
+	// Path is the file path in the repository.
+	Path string
+	// Offset is the offset of the file content in the index contents.
+	Offset int64
+	// Size is the size of the file content.
+	Size int64
+}
+
+// Index is a trigram index of the text files of a repository commit.
+//
+// Trigrams are computed on the lowercased file contents, so the index finds
+// candidate files for both case sensitive and insensitive queries.
+//
+// Index files store the file contents after the header holding the files and
+// trigrams. Loading an index only decodes the header, and searching reads the
+// contents of the candidate files. Loaded indexes must be closed.
+type Index struct {
+	// Commit is the indexed commit SHA.
+	Commit string
+	// Files are the indexed files.
+	Files []File
+	// Trigrams maps trigrams to the sorted indices of the files containing
+	// them.
+	Trigrams map[string][]int
+
+	// contents are the file contents of indexes built in memory.
+	contents []byte
+	// f is the index file of loaded indexes.
+	f *os.File
+	// offset is the offset of the file contents in f.
+	offset int64
+}
+
+// NewIndex returns an empty index of a commit.
+func NewIndex(commit string) *Index {
+	return &Index{
+		Commit:   commit,
+		Trigrams: map[string][]int{},
+	}
+}
+
+// Add adds a file to the index. Binary and large files are skipped.
+func (idx *Index) Add(path string, content []byte) {
+	if len(content) > MaxFileSize || isBinary(content) {
+		return
+	}
+
+	id := len(idx.Files)
+	idx.Files = append(idx.Files, File{
+		Path:   path,
+		Offset: int64(len(idx.contents)),
+		Size:   int64(len(content)),
+	})
+	idx.contents = append(idx.contents, content...)
+	for t := range trigrams(bytes.ToLower(content)) {
+		idx.Trigrams[t] = append(idx.Trigrams[t], id)
+	}
+}
+
+// Build indexes the files of a commit of the repository at path.
+func Build(ctx context.Context, path string, commit string) (*Index, error) {
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		var stderr bytes.Buffer
+		err := git.NewCommand("archive", "--format=tar", commit).
+			WithContext(ctx).
+			WithTimeout(-1).
+			RunInDirWithOptions(path, git.RunInDirOptions{
+				Stdout: pw,
+				Stderr: &stderr,
+			})
+		if err != nil {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		pw.CloseWithError(err) // nolint: errcheck
+		errc <- err
+	}()
+
+	idx := NewIndex(commit)
+	tr := tar.NewReader(pr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			pr.CloseWithError(err) // nolint: errcheck
+			<-errc
+			return nil, err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > MaxFileSize {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			pr.CloseWithError(err) // nolint: errcheck
+			<-errc
+			return nil, err
+		}
+
+		idx.Add(hdr.Name, content)
+	}
+
+	// Drain the archive padding so that git can exit.
+	if _, err := io.Copy(io.Discard, pr); err != nil {
+		<-errc
+		return nil, err
+	}
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// Load reads the header of an index file. The file stays open to read the
+// file contents when searching, until the index is closed.
+func Load(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := load(f)
+	if err != nil {
+		f.Close() // nolint: errcheck
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// load decodes the header of an index file.
+func load(f *os.File) (*Index, error) {
+	prefix := make([]byte, len(magic)+8)
+	if _, err := io.ReadFull(f, prefix); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+
+	if string(prefix[:len(magic)]) != magic {
+		return nil, ErrInvalidIndex
+	}
+
+	n := binary.BigEndian.Uint64(prefix[len(magic):])
+	var idx Index
+	if err := gob.NewDecoder(io.LimitReader(f, int64(n))).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+
+	idx.f = f
+	idx.offset = int64(len(prefix)) + int64(n)
+	return &idx, nil
+}
+
+// Close closes the file of a loaded index.
+func (idx *Index) Close() error {
+	if idx.f == nil {
+		return nil
+	}
+
+	return idx.f.Close()
+}
+
+// content returns the content of an indexed file.
+func (idx *Index) content(file File) ([]byte, error) {
+	if idx.f == nil {
+		return idx.contents[file.Offset : file.Offset+file.Size], nil
+	}
+
+	content := make([]byte, file.Size)
+	if _, err := idx.f.ReadAt(content, idx.offset+file.Offset); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// Save writes an index built in memory to a file. The file is replaced
+// atomically so that concurrent searches never read a partial index.
+func (idx *Index) Save(path string) error {
+	if idx.f != nil {
+		return errors.New("loaded indexes can't be saved")
+	}
+
+	var header bytes.Buffer
+	if err := gob.NewEncoder(&header).Encode(idx); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name()) // nolint: errcheck
+
+	prefix := binary.BigEndian.AppendUint64([]byte(magic), uint64(header.Len()))
+	for _, b := range [][]byte{prefix, header.Bytes(), idx.contents} {
+		if _, err := f.Write(b); err != nil {
+			f.Close() // nolint: errcheck
+			return err
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// trigrams returns the set of trigrams of b.
+func trigrams(b []byte) map[string]struct{} {
+	set := map[string]struct{}{}
+	for i := 0; i+3 <= len(b); i++ {
+		set[string(b[i:i+3])] = struct{}{}
+	}
+
+	return set
+}
+
+// isBinary returns whether content has a NUL byte in its first bytes.
+func isBinary(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+
+	return bytes.IndexByte(content, 0) >= 0
+}
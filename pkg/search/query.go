@@ -0,0 +1,205 @@
+package search
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum length of matched lines in results. Longer
+// lines are truncated.
+const maxLineLength = 256
+
+var (
+	// ErrEmptyQuery is returned when a search query is empty.
+	ErrEmptyQuery = errors.New("search query is empty")
+	// ErrInvalidRegex is returned when a regular expression query is invalid.
+	ErrInvalidRegex = errors.New("invalid regular expression")
+)
+
+// Match is a matched line.
+type Match struct {
+	// Path is the file path in the repository.
+	Path string
+	// Line is the line number, starting at 1.
+	Line int
+	// Text is the line text.
+	Text string
+}
+
+// Query is a compiled search query.
+type Query struct {
+	re       *regexp.Regexp
+	trigrams []string
+}
+
+// NewQuery compiles a search query. Queries are literal strings unless regex
+// is true, in which case they are Go regular expressions.
+func NewQuery(query string, regex bool) (*Query, error) {
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	expr := query
+	if !regex {
+		expr = regexp.QuoteMeta(query)
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRegex, err)
+	}
+
+	q := &Query{re: re}
+	var lits []string
+	if regex {
+		parsed, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRegex, err)
+		}
+
+		lits = literals(parsed.Simplify())
+	} else {
+		lits = []string{query}
+	}
+
+	seen := map[string]struct{}{}
+	for _, lit := range lits {
+		for t := range trigrams([]byte(strings.ToLower(lit))) {
+			if _, ok := seen[t]; !ok {
+				seen[t] = struct{}{}
+				q.trigrams = append(q.trigrams, t)
+			}
+		}
+	}
+
+	return q, nil
+}
+
+// Search returns up to limit lines matching the query. A limit of zero
+// returns all matches.
+func (idx *Index) Search(q *Query, limit int) ([]Match, error) {
+	var matches []Match
+	for _, id := range idx.candidates(q) {
+		f := idx.Files[id]
+		content, err := idx.content(f)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, line := range bytes.Split(content, []byte("\n")) {
+			if !q.re.Match(line) {
+				continue
+			}
+
+			matches = append(matches, Match{
+				Path: f.Path,
+				Line: i + 1,
+				Text: truncate(strings.TrimRight(string(line), "\r")),
+			})
+			if limit > 0 && len(matches) >= limit {
+				return matches, nil
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// candidates returns the indices of the files containing all the query
+// trigrams.
+func (idx *Index) candidates(q *Query) []int {
+	if len(q.trigrams) == 0 {
+		ids := make([]int, len(idx.Files))
+		for i := range ids {
+			ids[i] = i
+		}
+		return ids
+	}
+
+	ids := idx.Trigrams[q.trigrams[0]]
+	for _, t := range q.trigrams[1:] {
+		if len(ids) == 0 {
+			break
+		}
+		ids = intersect(ids, idx.Trigrams[t])
+	}
+
+	return ids
+}
+
+// literals returns literal strings that every match of a regular expression
+// contains.
+func literals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return literals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return literals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var lits []string
+		var cur strings.Builder
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				cur.WriteString(string(sub.Rune))
+				continue
+			}
+
+			if cur.Len() > 0 {
+				lits = append(lits, cur.String())
+				cur.Reset()
+			}
+			lits = append(lits, literals(sub)...)
+		}
+
+		if cur.Len() > 0 {
+			lits = append(lits, cur.String())
+		}
+
+		return lits
+	}
+
+	return nil
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+
+	return out
+}
+
+// truncate truncates long lines.
+func truncate(s string) string {
+	if len(s) <= maxLineLength {
+		return s
+	}
+
+	// Don't cut a rune in half.
+	n := maxLineLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n] + "…"
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testIndex() *Index {
+	idx := NewIndex("0123456789abcdef0123456789abcdef01234567")
+	idx.Add("main.go", []byte("package main\n\nfunc main() {\n\tprintln(\"Hello, World\")\n}\n"))
+	idx.Add("README.md", []byte("# Hello\n\nTODO(alice): write docs\n"))
+	idx.Add("logo.png", []byte("\x89PNG\x00\x00Hello"))
+	return idx
+}
+
+func TestSearch(t *testing.T) {
+	idx := testIndex()
+	cases := []struct {
+		name  string
+		query string
+		regex bool
+		limit int
+		want  []Match
+	}{
+		{
+			name:  "literal",
+			query: "Hello",
+			want: []Match{
+				{Path: "main.go", Line: 4, Text: "\tprintln(\"Hello, World\")"},
+				{Path: "README.md", Line: 1, Text: "# Hello"},
+			},
+		},
+		{
+			name:  "case sensitive",
+			query: "hello",
+		},
+		{
+			name:  "literal metacharacters",
+			query: "main()",
+			want:  []Match{{Path: "main.go", Line: 3, Text: "func main() {"}},
+		},
+		{
+			name:  "regex",
+			query: `TODO\(\w+\)`,
+			regex: true,
+			want:  []Match{{Path: "README.md", Line: 3, Text: "TODO(alice): write docs"}},
+		},
+		{
+			name:  "case insensitive regex",
+			query: `(?i)hello, world`,
+			regex: true,
+			want:  []Match{{Path: "main.go", Line: 4, Text: "\tprintln(\"Hello, World\")"}},
+		},
+		{
+			name:  "regex without literals",
+			query: `^\}$`,
+			regex: true,
+			want:  []Match{{Path: "main.go", Line: 5, Text: "}"}},
+		},
+		{
+			name:  "limit",
+			query: "Hello",
+			limit: 1,
+			want:  []Match{{Path: "main.go", Line: 4, Text: "\tprintln(\"Hello, World\")"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := NewQuery(c.query, c.regex)
+			if err != nil {
+				t.Fatalf("NewQuery() error = %v", err)
+			}
+
+			got, err := idx.Search(q, c.limit)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Search() = %#v, want %#v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewQueryInvalid(t *testing.T) {
+	if _, err := NewQuery("", false); !errors.Is(err, ErrEmptyQuery) {
+		t.Errorf("NewQuery() error = %v, want %v", err, ErrEmptyQuery)
+	}
+
+	if _, err := NewQuery("a(", true); !errors.Is(err, ErrInvalidRegex) {
+		t.Errorf("NewQuery() error = %v, want %v", err, ErrInvalidRegex)
+	}
+}
+
+func TestBuildSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "sub", "file.txt"), []byte("needle in a haystack\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	idx, err := Build(context.Background(), repo, "HEAD")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	path := filepath.Join(dir, "search", "1.idx")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	defer loaded.Close() // nolint: errcheck
+
+	q, _ := NewQuery("needle", false)
+	want := []Match{{Path: "sub/file.txt", Line: 1, Text: "needle in a haystack"}}
+	got, err := loaded.Search(q, 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "1.idx")
+	if err := os.WriteFile(path, []byte("not an index"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); !errors.Is(err, ErrInvalidIndex) {
+		t.Errorf("Load() error = %v, want %v", err, ErrInvalidIndex)
+	}
+}
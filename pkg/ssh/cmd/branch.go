@@ -133,7 +133,7 @@ func branchDefaultCommand() *cobra.Command {
 					return err
 				}
 
-				return webhook.SendEvent(ctx, wh)
+				return be.SendEvent(ctx, wh)
 			}
 
 			return nil
@@ -201,7 +201,7 @@ func branchDeleteCommand() *cobra.Command {
 				return err
 			}
 
-			return webhook.SendEvent(ctx, wh)
+			return be.SendEvent(ctx, wh)
 		},
 	}
 
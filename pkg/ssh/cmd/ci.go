@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func ciCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ci",
+		Short: "Manage repository CI runs",
+	}
+
+	cmd.AddCommand(
+		ciListCommand(),
+		ciLogsCommand(),
+		ciRunCommand(),
+	)
+
+	return cmd
+}
+
+func ciListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Aliases:           []string{"ls"},
+		Short:             "List the latest CI runs of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			runs, err := be.CIRuns(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			if len(runs) == 0 {
+				return nil
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				runs,
+				[]string{"ID", "Status", "Ref", "Commit", "Created At", "Duration"},
+				func(r models.CIRun) ([]string, error) {
+					var duration string
+					if r.StartedAt.Valid && r.FinishedAt.Valid {
+						duration = r.FinishedAt.Time.Sub(r.StartedAt.Time).String()
+					}
+
+					return []string{
+						strconv.FormatInt(r.ID, 10),
+						r.Status,
+						r.Ref,
+						r.CommitSHA,
+						humanize.Time(r.CreatedAt),
+						duration,
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func ciLogsCommand() *cobra.Command {
+	var follow bool
+	cmd := &cobra.Command{
+		Use:               "logs REPOSITORY RUN_ID",
+		Aliases:           []string{"log"},
+		Short:             "Show the log of a CI run",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			return be.CIRunLog(ctx, rn, id, cmd.OutOrStdout(), follow)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "stream the log until the run finishes")
+
+	return cmd
+}
+
+func ciRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "run REPOSITORY [REVISION]",
+		Short:             "Run the CI pipeline of a repository revision",
+		Long:              "Run the CI pipeline of a repository revision. It defaults to the default branch.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			var rev string
+			if len(args) > 1 {
+				rev = args[1]
+			}
+
+			id, err := be.QueueCIRun(ctx, rn, rev)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(id)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/git"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
@@ -109,6 +111,18 @@ func CommandName(args []string) string {
 	return args[0]
 }
 
+// allowAddr returns whether the remote address of the SSH session in the
+// context is allowed to access the given repository.
+func allowAddr(ctx context.Context, repo string) bool {
+	sess := sshutils.SessionFromContext(ctx)
+	if sess == nil {
+		return true
+	}
+
+	be := backend.FromContext(ctx)
+	return be.AllowAddr(ctx, repo, sess.RemoteAddr().String())
+}
+
 func checkIfReadable(cmd *cobra.Command, args []string) error {
 	var repo string
 	if len(args) > 0 {
@@ -118,6 +132,10 @@ func checkIfReadable(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	be := backend.FromContext(ctx)
 	rn := utils.SanitizeRepo(repo)
+	if !allowAddr(ctx, rn) {
+		return git.ErrAddrNotAllowed
+	}
+
 	user := proto.UserFromContext(ctx)
 	auth := be.AccessLevelForUser(cmd.Context(), rn, user)
 	if auth < access.ReadOnlyAccess {
@@ -178,6 +196,10 @@ func checkIfCollab(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	be := backend.FromContext(ctx)
 	rn := utils.SanitizeRepo(repo)
+	if !allowAddr(ctx, rn) {
+		return git.ErrAddrNotAllowed
+	}
+
 	user := proto.UserFromContext(ctx)
 	auth := be.AccessLevelForUser(cmd.Context(), rn, user)
 	if auth < access.ReadWriteAccess {
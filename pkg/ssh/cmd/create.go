@@ -15,6 +15,10 @@ func createCommand() *cobra.Command {
 	var description string
 	var projectName string
 	var hidden bool
+	var template string
+	var templateWebhooks bool
+	var templateCollabs bool
+	var templateDescription bool
 
 	cmd := &cobra.Command{
 		Use:               "create REPOSITORY",
@@ -32,6 +36,11 @@ func createCommand() *cobra.Command {
 				Description: description,
 				ProjectName: projectName,
 				Hidden:      hidden,
+
+				Template:            template,
+				TemplateWebhooks:    templateWebhooks,
+				TemplateCollabs:     templateCollabs,
+				TemplateDescription: templateDescription,
 			})
 			if err != nil {
 				return err
@@ -49,6 +58,10 @@ func createCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&description, "description", "d", "", "set the repository description")
 	cmd.Flags().StringVarP(&projectName, "name", "n", "", "set the project name")
 	cmd.Flags().BoolVarP(&hidden, "hidden", "H", false, "hide the repository from the UI")
+	cmd.Flags().StringVarP(&template, "template", "t", "", "initialize the repository from a template repository")
+	cmd.Flags().BoolVar(&templateWebhooks, "template-webhooks", false, "copy the template's webhooks")
+	cmd.Flags().BoolVar(&templateCollabs, "template-collabs", false, "copy the template's collaborators")
+	cmd.Flags().BoolVar(&templateDescription, "template-description", false, "use the template's description if none is given")
 
 	return cmd
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func daemonPushCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon-push REPOSITORY [TRUE|FALSE]",
+		Short: "Set or get whether anonymous pushes over the Git daemon are allowed",
+		Long: `Set or get whether anonymous pushes over the Git daemon are allowed.
+
+Anonymous pushes are only accepted when the server anon-access setting is
+read-write or higher. Only enable this on trusted networks.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				if err := checkIfReadable(cmd, args); err != nil {
+					return err
+				}
+
+				daemonPush, err := be.DaemonPush(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(daemonPush)
+			case 2:
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+
+				daemonPush, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid value: %w", err)
+				}
+
+				if err := be.SetDaemonPush(ctx, repo, daemonPush); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func gateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "gate",
+		Aliases: []string{"gates"},
+		Short:   "Manage repository branch gates",
+		Long: `Manage repository branch gates.
+
+Updates to branches matching a gate must be approved by an HTTP endpoint or a
+local command. HTTP gates receive a signed JSON request and approve updates
+with a 2xx response. Commands are run with the ref name, old SHA, and new SHA
+as arguments and the JSON request on stdin, and approve updates by exiting
+with a zero status. The response body or command output is shown to the
+pusher when an update is denied.`,
+	}
+
+	cmd.AddCommand(
+		gateListCommand(),
+		gateAddCommand(),
+		gateRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func gateListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List repository branch gates",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rules, err := be.GateRules(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				rules,
+				[]string{"ID", "Branch", "Target", "Timeout", "Created At"},
+				func(r models.GateRule) ([]string, error) {
+					target := r.URL
+					if target == "" {
+						target = r.Command
+					}
+
+					return []string{
+						strconv.FormatInt(r.ID, 10),
+						r.Branch,
+						target,
+						(time.Duration(r.Timeout) * time.Second).String(),
+						humanize.Time(r.CreatedAt),
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func gateAddCommand() *cobra.Command {
+	var url string
+	var command string
+	var secret string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "add REPOSITORY BRANCH",
+		Short: "Add a branch gate to a repository",
+		Long: `Add a branch gate to a repository.
+
+The branch is a glob pattern where * doesn't match / and ** matches anything.`,
+		Example: `  ssh soft repo gate add icecream main --url https://ci.example.com/gate -s secret
+  ssh soft repo gate add icecream 'release/*' --command /usr/local/bin/check-release`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Command gates run on the server, so only server admins can add
+			// them. Repository admins can add HTTP gates.
+			command = strings.TrimSpace(command)
+			if command != "" {
+				if err := checkIfAdmin(cmd, nil); err != nil {
+					return fmt.Errorf("only server admins can add command gates: %w", err)
+				}
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := be.AddGateRule(ctx, args[0], args[1], strings.TrimSpace(url), command, secret, timeout)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&url, "url", "u", "", "HTTP endpoint that approves updates")
+	cmd.Flags().StringVarP(&command, "command", "c", "", "path of a local command that approves updates, only server admins can add command gates")
+	cmd.Flags().StringVarP(&secret, "secret", "s", "", "secret to sign the HTTP request body")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 0, "gate timeout, defaults to 30s")
+
+	return cmd
+}
+
+func gateRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY GATE_ID",
+		Aliases:           []string{"rm", "delete"},
+		Short:             "Remove a repository branch gate",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid gate ID: %w", err)
+			}
+
+			return be.RemoveGateRule(ctx, args[0], id)
+		},
+	}
+
+	return cmd
+}
@@ -185,6 +185,10 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if !allowAddr(ctx, name) {
+		return git.ErrAddrNotAllowed
+	}
+
 	// Set repo in context
 	repo, _ := be.Repository(ctx, name)
 	ctx = proto.WithRepositoryContext(ctx, repo)
@@ -208,7 +212,7 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 	// Add GIT_PROTOCOL from session.
 	if sess := sshutils.SessionFromContext(ctx); sess != nil {
 		for _, env := range sess.Environ() {
-			if strings.HasPrefix(env, "GIT_PROTOCOL=") {
+			if strings.HasPrefix(env, git.ProtocolEnv+"=") {
 				envs = append(envs, env)
 				break
 			}
@@ -237,6 +241,9 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		if accessLevel < access.ReadWriteAccess {
 			return git.ErrNotAuthed
 		}
+		if repo != nil && repo.IsArchived() {
+			return git.ErrArchived
+		}
 		if repo == nil {
 			if _, err := be.CreateRepository(ctx, name, user, proto.RepositoryOptions{Private: false}); err != nil {
 				log.Errorf("failed to create repo: %s", err)
@@ -315,6 +322,10 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			return git.ErrInvalidRepo
 		}
 
+		if operation == lfs.OperationUpload && repo.IsArchived() {
+			return git.ErrArchived
+		}
+
 		scmd.Args = []string{
 			name,
 			args[1],
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func ipCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ip",
+		Aliases: []string{"ip-rules"},
+		Short:   "Manage repository IP access rules",
+		Long: `Manage repository IP access rules.
+
+Rules are CIDRs or single IP addresses. Deny rules take precedence, and when a
+repository has any allow rules, only matching addresses can access it.`,
+	}
+
+	cmd.AddCommand(
+		ipListCommand(),
+		ipAddCommand(true),
+		ipAddCommand(false),
+		ipRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func ipListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List repository IP access rules",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rules, err := be.IPRules(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				rules,
+				[]string{"ID", "CIDR", "Action", "Created At"},
+				func(r models.IPRule) ([]string, error) {
+					action := "deny"
+					if r.Allow {
+						action = "allow"
+					}
+
+					return []string{
+						strconv.FormatInt(r.ID, 10),
+						r.CIDR,
+						action,
+						humanize.Time(r.CreatedAt),
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func ipAddCommand(allow bool) *cobra.Command {
+	use, short := "deny", "Deny an address range from accessing a repository"
+	if allow {
+		use, short = "allow", "Allow an address range to access a repository"
+	}
+
+	cmd := &cobra.Command{
+		Use:               use + " REPOSITORY CIDR",
+		Short:             short,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := be.AddIPRule(ctx, args[0], args[1], allow)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(id)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func ipRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY RULE_ID",
+		Short:             "Remove a repository IP access rule",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid rule ID: %w", err)
+			}
+
+			return be.RemoveIPRule(ctx, args[0], id)
+		},
+	}
+
+	return cmd
+}
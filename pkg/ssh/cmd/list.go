@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/spf13/cobra"
 )
@@ -10,6 +16,10 @@ import (
 // listCommand returns a command that list file or directory at path.
 func listCommand() *cobra.Command {
 	var all bool
+	var topics []string
+	var owner string
+	var sortBy string
+	var starred bool
 
 	listCmd := &cobra.Command{
 		Use:     "list",
@@ -20,14 +30,56 @@ func listCommand() *cobra.Command {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			pk := sshutils.PublicKeyFromContext(ctx)
-			repos, err := be.Repositories(ctx)
+
+			var ownerID int64
+			if owner != "" {
+				u, err := be.User(ctx, owner)
+				if err != nil {
+					return err
+				}
+				ownerID = u.ID()
+			}
+
+			var repos []proto.Repository
+			var err error
+			if starred {
+				repos, err = be.StarredRepositories(ctx, proto.UserFromContext(ctx))
+			} else {
+				repos, err = be.Repositories(ctx)
+			}
 			if err != nil {
 				return err
 			}
+
+			switch sortBy {
+			case "":
+			case "name":
+				sort.SliceStable(repos, func(i, j int) bool {
+					return repos[i].Name() < repos[j].Name()
+				})
+			case "updated":
+				sort.SliceStable(repos, func(i, j int) bool {
+					return repos[i].UpdatedAt().After(repos[j].UpdatedAt())
+				})
+			case "created":
+				sort.SliceStable(repos, func(i, j int) bool {
+					return repos[i].CreatedAt().After(repos[j].CreatedAt())
+				})
+			default:
+				return fmt.Errorf("invalid sort %q, must be one of: name, updated, created", sortBy)
+			}
+
 			for _, r := range repos {
+				if !matchesRepo(r, ownerID, topics) {
+					continue
+				}
 				if be.AccessLevelByPublicKey(ctx, r.Name(), pk) >= access.ReadOnlyAccess {
 					if !r.IsHidden() || all {
-						cmd.Println(r.Name())
+						if r.IsArchived() {
+							cmd.Println(r.Name(), "(archived)")
+						} else {
+							cmd.Println(r.Name())
+						}
 					}
 				}
 			}
@@ -36,6 +88,26 @@ func listCommand() *cobra.Command {
 	}
 
 	listCmd.Flags().BoolVarP(&all, "all", "a", false, "List all repositories")
+	listCmd.Flags().StringSliceVarP(&topics, "topic", "t", nil, "List repositories with the given topics")
+	listCmd.Flags().StringVarP(&owner, "owner", "o", "", "List repositories owned by the given user")
+	listCmd.Flags().StringVarP(&sortBy, "sort", "s", "", "Sort repositories by name, updated, or created")
+	listCmd.Flags().BoolVar(&starred, "starred", false, "List your starred repositories")
 
 	return listCmd
 }
+
+// matchesRepo returns whether a repository is owned by the given owner and
+// has all the given topics. Zero owner IDs match any owner.
+func matchesRepo(r proto.Repository, ownerID int64, topics []string) bool {
+	if ownerID > 0 && r.UserID() != ownerID {
+		return false
+	}
+
+	for _, t := range topics {
+		if !slices.Contains(r.Topics(), strings.ToLower(t)) {
+			return false
+		}
+	}
+
+	return true
+}
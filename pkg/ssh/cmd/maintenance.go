@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func maintenanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Manage repository maintenance",
+	}
+
+	cmd.AddCommand(
+		maintenanceRunCommand(),
+		maintenanceStatusCommand(),
+	)
+
+	return cmd
+}
+
+func maintenanceRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "run REPOSITORY",
+		Short:             "Repack a repository and write its commit-graph",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			res, err := be.MaintainRepository(ctx, args[0], true)
+			if err != nil {
+				return err
+			}
+
+			if !res.Ran {
+				cmd.Println("Repository is empty")
+				return nil
+			}
+
+			cmd.Printf("Maintained %s in %s\n", res.Repo, res.Duration.Round(time.Millisecond))
+			cmd.Printf("Loose objects: %d -> %d\n", res.Before.Loose, res.After.Loose)
+			cmd.Printf("Packs: %d -> %d\n", res.Before.Packs, res.After.Packs)
+			cmd.Printf("Size: %s -> %s (saved %s)\n",
+				humanize.IBytes(uint64(res.Before.Size)),
+				humanize.IBytes(uint64(res.After.Size)),
+				savedBytes(res.SavedBytes()),
+			)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func maintenanceStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "status REPOSITORY",
+		Short:             "Show the object storage of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			o, err := be.RepositoryObjects(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Loose objects: %d\n", o.Loose)
+			cmd.Printf("Packs: %d\n", o.Packs)
+			cmd.Printf("Size: %s\n", humanize.IBytes(uint64(o.Size)))
+			cmd.Printf("Commit-graph: %t\n", o.CommitGraph)
+			cmd.Printf("Bitmap index: %t\n", o.Bitmap)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// savedBytes formats a number of saved bytes, which can be negative when a
+// repository grows.
+func savedBytes(n int64) string {
+	if n < 0 {
+		return fmt.Sprintf("-%s", humanize.IBytes(uint64(-n)))
+	}
+
+	return humanize.IBytes(uint64(n))
+}
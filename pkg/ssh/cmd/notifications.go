@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// NotificationsCommand returns a command that manages the notifications of
+// watched repositories.
+func NotificationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "notifications",
+		Aliases: []string{"notification", "notifs"},
+		Short:   "Manage notifications of watched repositories",
+	}
+
+	cmd.AddCommand(
+		notificationsListCommand(),
+		notificationsReadCommand(),
+		notificationsClearCommand(),
+	)
+
+	return cmd
+}
+
+func notificationsListCommand() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List unread notifications",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			ns, err := be.Notifications(ctx, proto.UserFromContext(ctx), all)
+			if err != nil {
+				return err
+			}
+
+			if len(ns) == 0 {
+				return nil
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				ns,
+				[]string{"ID", "Message", "Read", "Created At"},
+				func(n models.Notification) ([]string, error) {
+					message, _, _ := strings.Cut(n.Message, "\n")
+					return []string{
+						strconv.FormatInt(n.ID, 10),
+						message,
+						strconv.FormatBool(n.Read),
+						humanize.Time(n.CreatedAt),
+					}, nil
+				},
+			)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include read notifications")
+
+	return cmd
+}
+
+func notificationsReadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read [ID...]",
+		Short: "Mark notifications as read",
+		Long:  "Mark notifications as read. All notifications are marked as read when no IDs are given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			ids := make([]int64, len(args))
+			for i, arg := range args {
+				id, err := strconv.ParseInt(arg, 10, 64)
+				if err != nil {
+					return proto.ErrNotificationNotFound
+				}
+				ids[i] = id
+			}
+
+			return be.MarkNotificationsRead(ctx, proto.UserFromContext(ctx), ids...)
+		},
+	}
+
+	return cmd
+}
+
+func notificationsClearCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete read notifications",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.ClearNotifications(ctx, proto.UserFromContext(ctx))
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func releaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "release",
+		Aliases: []string{"releases"},
+		Short:   "Manage repository releases",
+	}
+
+	cmd.AddCommand(
+		releaseListCommand(),
+		releaseCreateCommand(),
+		releaseUploadCommand(),
+		releaseDeleteCommand(),
+	)
+
+	return cmd
+}
+
+func releaseListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list REPOSITORY [TAG]",
+		Aliases: []string{"ls"},
+		Short:   "List repository releases or the assets of a release",
+		Long: `List repository releases.
+
+When a tag is given, print the release notes and list the release assets.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			if len(args) == 2 {
+				release, assets, err := be.Release(ctx, rn, args[1])
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(release.Title)
+				if notes := strings.TrimSpace(release.Notes); notes != "" {
+					cmd.Println()
+					cmd.Println(notes)
+				}
+
+				if len(assets) == 0 {
+					return nil
+				}
+
+				cmd.Println()
+				return tablewriter.Render(
+					cmd.OutOrStdout(),
+					assets,
+					[]string{"Name", "Size", "Updated At"},
+					func(a models.ReleaseAsset) ([]string, error) {
+						return []string{
+							a.Name,
+							humanize.Bytes(uint64(a.Size)),
+							humanize.Time(a.UpdatedAt),
+						}, nil
+					},
+				)
+			}
+
+			releases, err := be.Releases(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				releases,
+				[]string{"Tag", "Title", "Created At"},
+				func(r models.Release) ([]string, error) {
+					return []string{
+						r.Tag,
+						r.Title,
+						humanize.Time(r.CreatedAt),
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func releaseCreateCommand() *cobra.Command {
+	var title string
+	var notes string
+	cmd := &cobra.Command{
+		Use:               "create REPOSITORY TAG",
+		Short:             "Create a release from an existing tag",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			_, err := be.CreateRelease(ctx, rn, args[1], strings.TrimSpace(title), notes)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "release title, defaults to the tag name")
+	cmd.Flags().StringVarP(&notes, "notes", "n", "", "release notes in markdown")
+
+	return cmd
+}
+
+func releaseUploadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upload REPOSITORY TAG NAME",
+		Short: "Upload a release asset",
+		Long: `Upload a release asset read from standard input.
+
+An existing asset with the same name is replaced.`,
+		Example:           "  ssh soft repo release upload icecream v1.0.0 icecream.tar.gz < icecream.tar.gz",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			asset, err := be.UploadReleaseAsset(ctx, rn, args[1], args[2], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(humanize.Bytes(uint64(asset.Size)))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func releaseDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY TAG",
+		Aliases:           []string{"remove", "rm", "del"},
+		Short:             "Delete a release and its assets",
+		Long:              "Delete a release and its assets. The tag is kept.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			return be.DeleteRelease(ctx, rn, args[1])
+		},
+	}
+
+	return cmd
+}
@@ -19,22 +19,39 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		archiveCommand(),
 		blobCommand(renderer),
 		branchCommand(),
+		ciCommand(),
 		collabCommand(),
 		commitCommand(renderer),
 		createCommand(),
+		daemonPushCommand(),
 		deleteCommand(),
 		descriptionCommand(),
+		gateCommand(),
 		hiddenCommand(),
 		importCommand(),
+		ipCommand(),
 		listCommand(),
+		maintenanceCommand(),
 		mirrorCommand(),
 		privateCommand(),
 		projectName(),
+		releaseCommand(),
 		renameCommand(),
+		starCommand(),
+		statusCommand(),
 		tagCommand(),
+		templateCommand(),
+		topicCommand(),
+		transferCommand(),
+		trashCommand(),
 		treeCommand(),
+		unarchiveCommand(),
+		unstarCommand(),
+		unwatchCommand(),
+		watchCommand(),
 		webhookCommand(),
 	)
 
@@ -71,6 +88,11 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 					}
 				}
 
+				stars, err := be.StarCount(ctx, rn)
+				if err != nil {
+					return err
+				}
+
 				branches, _ := r.Branches()
 				tags, _ := r.Tags()
 
@@ -82,6 +104,12 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 				cmd.Println("Private:", rr.IsPrivate())
 				cmd.Println("Hidden:", rr.IsHidden())
 				cmd.Println("Mirror:", rr.IsMirror())
+				cmd.Println("Archived:", rr.IsArchived())
+				cmd.Println("Template:", rr.IsTemplate())
+				cmd.Println("Stars:", stars)
+				if topics := rr.Topics(); len(topics) > 0 {
+					cmd.Println("Topics:", strings.Join(topics, ", "))
+				}
 				if owner != nil {
 					cmd.Println(strings.TrimSpace(fmt.Sprint("Owner: ", owner.Username())))
 				}
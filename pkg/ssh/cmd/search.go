@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/spf13/cobra"
+)
+
+// SearchCommand returns a command that searches the code of repositories.
+func SearchCommand() *cobra.Command {
+	var opts backend.SearchOptions
+	cmd := &cobra.Command{
+		Use:   "search QUERY",
+		Short: "Search code across repositories",
+		Long: `Search the default branch of the repositories you can read.
+
+Results are printed as REPOSITORY:PATH:LINE:TEXT.`,
+		Example: `  ssh soft search "func main"
+  ssh soft search -E 'TODO\(\w+\)' --repo icecream`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+			if sess := sshutils.SessionFromContext(ctx); sess != nil {
+				opts.Addr = sess.RemoteAddr().String()
+			}
+
+			results, err := be.Search(ctx, user, args[0], opts)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range results {
+				cmd.Printf("%s:%s:%d:%s\n", r.Repo, r.Path, r.Line, r.Text)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "r", "", "only search a repository")
+	cmd.Flags().BoolVarP(&opts.Regex, "regex", "E", false, "treat the query as a regular expression")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", backend.DefaultSearchLimit, "maximum number of results")
+
+	return cmd
+}
@@ -67,6 +67,7 @@ func SettingsCommand() *cobra.Command {
 				return nil
 			},
 		},
+		systemWebhookCommand(),
 	)
 
 	return cmd
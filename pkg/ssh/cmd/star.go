@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func starCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "star REPOSITORY",
+		Short:             "Star a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.StarRepository(ctx, args[0], proto.UserFromContext(ctx))
+		},
+	}
+
+	return cmd
+}
+
+func unstarCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unstar REPOSITORY",
+		Short:             "Unstar a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.UnstarRepository(ctx, args[0], proto.UserFromContext(ctx))
+		},
+	}
+
+	return cmd
+}
+
+func watchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch REPOSITORY",
+		Short: "Watch a repository",
+		Long: `Watch a repository.
+
+You get notified of the pushes and other events of watched repositories. Use
+the notifications command to read them.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.WatchRepository(ctx, args[0], proto.UserFromContext(ctx))
+		},
+	}
+
+	return cmd
+}
+
+func unwatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unwatch REPOSITORY",
+		Short:             "Unwatch a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.UnwatchRepository(ctx, args[0], proto.UserFromContext(ctx))
+		},
+	}
+
+	return cmd
+}
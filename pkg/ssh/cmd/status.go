@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func statusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"statuses"},
+		Short:   "Manage repository commit statuses",
+	}
+
+	cmd.AddCommand(
+		statusListCommand(),
+		statusSetCommand(),
+	)
+
+	return cmd
+}
+
+func statusListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY REVISION",
+		Aliases:           []string{"ls", "get"},
+		Short:             "List the statuses of a commit",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			sha, statuses, err := be.CommitStatuses(ctx, rn, args[1])
+			if err != nil {
+				return err
+			}
+
+			states := make([]proto.CommitStatusState, len(statuses))
+			for i, s := range statuses {
+				states[i] = proto.CommitStatusState(s.State)
+			}
+
+			cmd.Println(sha)
+			if len(statuses) == 0 {
+				return nil
+			}
+
+			cmd.Println(proto.CombinedCommitStatusState(states...))
+			cmd.Println()
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				statuses,
+				[]string{"Context", "State", "Description", "Target URL", "Updated At"},
+				func(s models.CommitStatus) ([]string, error) {
+					return []string{
+						s.Context,
+						s.State,
+						s.Description,
+						s.TargetURL,
+						humanize.Time(s.UpdatedAt),
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func statusSetCommand() *cobra.Command {
+	var statusContext string
+	var targetURL string
+	var description string
+	cmd := &cobra.Command{
+		Use:   "set REPOSITORY REVISION STATE",
+		Short: "Set the status of a commit",
+		Long: `Set the status of a commit for a context.
+
+The state can be one of pending, success, failure, or error. An existing
+status with the same context is replaced.`,
+		Example:           "  ssh soft repo status set icecream main success -c ci/build -u https://ci.example.com/builds/1",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			state, err := proto.ParseCommitStatusState(args[2])
+			if err != nil {
+				return err
+			}
+
+			_, err = be.SetCommitStatus(ctx, rn, args[1], state, statusContext, strings.TrimSpace(targetURL), description)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&statusContext, "context", "c", backend.DefaultCommitStatusContext, "status context, usually the name of the CI job")
+	cmd.Flags().StringVarP(&targetURL, "url", "u", "", "URL of the status details")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "short description of the status")
+
+	return cmd
+}
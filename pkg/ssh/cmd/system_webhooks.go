@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func systemWebhookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "webhook",
+		Aliases:           []string{"webhooks"},
+		Short:             "Manage system webhooks",
+		PersistentPreRunE: checkIfAdmin,
+	}
+
+	cmd.AddCommand(
+		systemWebhookListCommand(),
+		systemWebhookCreateCommand(),
+		systemWebhookDeleteCommand(),
+		systemWebhookUpdateCommand(),
+		systemWebhookPingCommand(),
+		systemWebhookDeliveriesCommand(),
+	)
+
+	return cmd
+}
+
+func systemWebhookListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List system webhooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			webhooks, err := be.ListSystemWebhooks(ctx)
+			if err != nil {
+				return err
+			}
+
+			return renderWebhooks(cmd, webhooks)
+		},
+	}
+
+	return cmd
+}
+
+func systemWebhookCreateCommand() *cobra.Command {
+	var flags webhookCreateFlags
+	cmd := &cobra.Command{
+		Use:   "create URL",
+		Short: "Create a system webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			evs, ct, tmpl, err := flags.parse(cmd, true)
+			if err != nil {
+				return err
+			}
+
+			return be.CreateSystemWebhook(ctx, strings.TrimSpace(args[0]), ct, flags.secret, evs, flags.active, tmpl)
+		},
+	}
+
+	addWebhookCreateFlags(cmd, &flags, systemWebhookEvents)
+
+	return cmd
+}
+
+func systemWebhookDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete WEBHOOK_ID",
+		Short: "Delete a system webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			return be.DeleteSystemWebhook(ctx, id)
+		},
+	}
+
+	return cmd
+}
+
+func systemWebhookUpdateCommand() *cobra.Command {
+	var flags webhookUpdateFlags
+	cmd := &cobra.Command{
+		Use:   "update WEBHOOK_ID",
+		Short: "Update a system webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			wh, err := be.SystemWebhook(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			wh, tmpl, err := flags.apply(cmd, wh, true)
+			if err != nil {
+				return err
+			}
+
+			return be.UpdateSystemWebhook(ctx, id, wh.URL, wh.ContentType, wh.Secret, wh.Events, wh.Active, tmpl)
+		},
+	}
+
+	addWebhookUpdateFlags(cmd, &flags, systemWebhookEvents)
+
+	return cmd
+}
+
+func systemWebhookPingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ping WEBHOOK_ID",
+		Short: "Send a ping event to a system webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			del, err := be.PingSystemWebhook(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			printWebhookDelivery(cmd, del)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func systemWebhookDeliveriesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deliveries",
+		Short:   "Manage system webhook deliveries",
+		Aliases: []string{"delivery", "deliver"},
+	}
+
+	cmd.AddCommand(
+		systemWebhookDeliveriesListCommand(),
+		systemWebhookDeliveriesRedeliverCommand(),
+		systemWebhookDeliveriesGetCommand(),
+	)
+
+	return cmd
+}
+
+func systemWebhookDeliveriesListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list WEBHOOK_ID",
+		Short: "List system webhook deliveries",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			if _, err := be.SystemWebhook(ctx, id); err != nil {
+				return err
+			}
+
+			dels, err := be.ListWebhookDeliveries(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return renderWebhookDeliveries(cmd, dels)
+		},
+	}
+
+	return cmd
+}
+
+func systemWebhookDeliveriesRedeliverCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redeliver WEBHOOK_ID DELIVERY_ID",
+		Short: "Redeliver a system webhook delivery",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			delID, err := uuid.Parse(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid delivery ID: %w", err)
+			}
+
+			return be.RedeliverSystemWebhookDelivery(ctx, id, delID)
+		},
+	}
+
+	return cmd
+}
+
+func systemWebhookDeliveriesGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get WEBHOOK_ID DELIVERY_ID",
+		Short: "Get a system webhook delivery",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			delID, err := uuid.Parse(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid delivery ID: %w", err)
+			}
+
+			if _, err := be.SystemWebhook(ctx, id); err != nil {
+				return err
+			}
+
+			del, err := be.WebhookDelivery(ctx, id, delID)
+			if err != nil {
+				return err
+			}
+
+			printWebhookDelivery(cmd, del)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
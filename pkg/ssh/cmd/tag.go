@@ -112,7 +112,17 @@ func tagDeleteCommand() *cobra.Command {
 				return err
 			}
 
-			return webhook.SendEvent(ctx, wh)
+			if err := be.SendEvent(ctx, wh); err != nil {
+				return err
+			}
+
+			tagEvent, err := webhook.NewTagEvent(ctx, proto.UserFromContext(ctx), rr, git.RefsTags+tag, tagCommit.ID.String(), git.ZeroID)
+			if err != nil {
+				log.Error("failed to create tag webhook", "err", err)
+				return err
+			}
+
+			return be.SendEvent(ctx, tagEvent)
 		},
 	}
 
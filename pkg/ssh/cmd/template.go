@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func templateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template REPOSITORY [TRUE|FALSE]",
+		Short: "Set or get whether a repository is a template",
+		Long: `Set or get whether a repository is a template.
+
+Template repositories can be used to create new repositories with
+"repo create --template".`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				if err := checkIfReadable(cmd, args); err != nil {
+					return err
+				}
+
+				template, err := be.IsTemplate(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(template)
+			case 2:
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+
+				template, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid value: %w", err)
+				}
+
+				if err := be.SetTemplate(ctx, repo, template); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func topicCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "topic",
+		Aliases: []string{"topics"},
+		Short:   "Manage repository topics",
+	}
+
+	cmd.AddCommand(
+		topicAddCommand(),
+		topicRemoveCommand(),
+		topicListCommand(),
+	)
+
+	return cmd
+}
+
+func topicAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add REPOSITORY TOPIC...",
+		Short:             "Add topics to a repository",
+		Long:              "Add topics to a repository. Topics can contain lowercase letters, numbers, and hyphens.",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			return be.AddRepositoryTopics(ctx, repo, args[1:]...)
+		},
+	}
+
+	return cmd
+}
+
+func topicRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY TOPIC...",
+		Aliases:           []string{"rm"},
+		Short:             "Remove topics from a repository",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			return be.RemoveRepositoryTopics(ctx, repo, args[1:]...)
+		},
+	}
+
+	return cmd
+}
+
+func topicListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Aliases:           []string{"ls"},
+		Short:             "List repository topics",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			r, err := be.Repository(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			for _, t := range r.Topics() {
+				cmd.Println(t)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
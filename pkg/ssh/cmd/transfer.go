@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func transferCommand() *cobra.Command {
+	var keepAccess bool
+
+	cmd := &cobra.Command{
+		Use:   "transfer REPOSITORY NEW_OWNER",
+		Short: "Transfer a repository to another user",
+		Long: `Transfer a repository to another user.
+
+The new owner is removed from the repository collaborators. Use --keep-access
+to keep the previous owner as a read-write collaborator.`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			newOwner := args[1]
+
+			return be.TransferRepository(ctx, repo, newOwner, keepAccess)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&keepAccess, "keep-access", "k", false, "keep the previous owner as a read-write collaborator")
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/caarlos0/tablewriter"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func trashCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage deleted repositories",
+		Long: `Manage deleted repositories.
+
+Deleted repositories are kept in the trash until the retention period expires.`,
+		// The arguments are trash IDs, not repositories.
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return checkIfAdmin(cmd, nil)
+		},
+	}
+
+	cmd.AddCommand(
+		trashListCommand(),
+		trashPurgeCommand(),
+		trashRestoreCommand(),
+	)
+
+	return cmd
+}
+
+func trashListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List deleted repositories",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repos, err := be.TrashedRepositories(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(repos) == 0 {
+				return nil
+			}
+
+			return tablewriter.Render(
+				cmd.OutOrStdout(),
+				repos,
+				[]string{"ID", "Name", "Deleted By", "Deleted At", "Expires"},
+				func(r models.TrashedRepo) ([]string, error) {
+					var deletedBy string
+					if r.UserID.Valid {
+						if u, err := be.UserByID(ctx, r.UserID.Int64); err == nil {
+							deletedBy = u.Username()
+						}
+					}
+
+					return []string{
+						strconv.FormatInt(r.ID, 10),
+						r.Name,
+						deletedBy,
+						humanize.Time(r.CreatedAt),
+						humanize.Time(be.TrashExpiresAt(r)),
+					}, nil
+				},
+			)
+		},
+	}
+
+	return cmd
+}
+
+func trashRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore ID [NAME]",
+		Short: "Restore a deleted repository",
+		Long:  "Restore a deleted repository. It gets its original name back unless NAME is set.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			var name string
+			if len(args) > 1 {
+				name = args[1]
+			}
+
+			r, err := be.RestoreRepository(ctx, id, name)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Restored", r.Name())
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func trashPurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge ID",
+		Short: "Permanently delete a deleted repository",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			return be.PurgeRepository(ctx, id)
+		},
+	}
+
+	return cmd
+}
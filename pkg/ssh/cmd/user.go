@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"sort"
 	"strings"
 
@@ -53,9 +54,14 @@ func UserCommand() *cobra.Command {
 	userCreateCommand.Flags().BoolVarP(&admin, "admin", "a", false, "make the user an admin")
 	userCreateCommand.Flags().StringVarP(&key, "key", "k", "", "add a public key to the user")
 
+	var transferTo string
 	userDeleteCommand := &cobra.Command{
-		Use:               "delete USERNAME",
-		Short:             "Delete a user",
+		Use:   "delete USERNAME",
+		Short: "Delete a user",
+		Long: `Delete a user.
+
+The user's repositories are deleted along with the user unless they are
+transferred to another user with --transfer-to.`,
 		Args:              cobra.ExactArgs(1),
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,10 +69,26 @@ func UserCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			username := args[0]
 
+			if transferTo != "" {
+				if strings.EqualFold(transferTo, username) {
+					return errors.New("cannot transfer repositories to the deleted user")
+				}
+
+				if _, err := be.User(ctx, transferTo); err != nil {
+					return err
+				}
+
+				if err := be.TransferUserRepositories(ctx, username, transferTo); err != nil {
+					return err
+				}
+			}
+
 			return be.DeleteUser(ctx, username)
 		},
 	}
 
+	userDeleteCommand.Flags().StringVarP(&transferTo, "transfer-to", "t", "", "transfer the user's repositories to another user")
+
 	userListCommand := &cobra.Command{
 		Use:               "list",
 		Aliases:           []string{"ls"},
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/tablewriter"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
@@ -25,22 +27,206 @@ func webhookCommand() *cobra.Command {
 		webhookCreateCommand(),
 		webhookDeleteCommand(),
 		webhookUpdateCommand(),
+		webhookPingCommand(),
 		webhookDeliveriesCommand(),
 	)
 
 	return cmd
 }
 
-var webhookEvents []string
+var (
+	webhookEvents       []string
+	systemWebhookEvents []string
+)
 
 func init() {
-	events := webhook.Events()
-	webhookEvents = make([]string, len(events))
-	for i, e := range events {
-		webhookEvents[i] = e.String()
+	for _, e := range webhook.Events() {
+		if !e.IsServerEvent() {
+			webhookEvents = append(webhookEvents, e.String())
+		}
+		systemWebhookEvents = append(systemWebhookEvents, e.String())
+	}
+}
+
+// parseWebhookEvents parses the events flag of a webhook. Server events are
+// only allowed for system webhooks.
+func parseWebhookEvents(events []string, system bool) ([]webhook.Event, error) {
+	var evs []webhook.Event
+	for _, e := range events {
+		ev, err := webhook.ParseEvent(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event: %w", err)
+		}
+
+		if ev.IsServerEvent() && !system {
+			return nil, fmt.Errorf("invalid event: %s is only available for system webhooks", ev)
+		}
+
+		evs = append(evs, ev)
+	}
+
+	return evs, nil
+}
+
+// parseWebhookContentType parses the content type flag of a webhook.
+func parseWebhookContentType(s string) (webhook.ContentType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return webhook.ContentTypeJSON, nil
+	case "form":
+		return webhook.ContentTypeForm, nil
+	case "slack":
+		return webhook.ContentTypeSlack, nil
+	case "discord":
+		return webhook.ContentTypeDiscord, nil
+	case "matrix":
+		return webhook.ContentTypeMatrix, nil
+	default:
+		return -1, webhook.ErrInvalidContentType
 	}
 }
 
+// webhookTemplate reads a webhook template that is set to `-` from stdin.
+func webhookTemplate(cmd *cobra.Command, tmpl webhook.Template) (webhook.Template, error) {
+	if tmpl.Body == "-" && tmpl.Headers == "-" {
+		return tmpl, fmt.Errorf("only one template can be read from stdin")
+	}
+
+	for _, t := range []*string{&tmpl.Body, &tmpl.Headers} {
+		if *t != "-" {
+			continue
+		}
+
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return tmpl, err
+		}
+
+		*t = string(b)
+	}
+
+	return tmpl, nil
+}
+
+// webhookCreateFlags are the flags of the commands that create repository and
+// system webhooks.
+type webhookCreateFlags struct {
+	events          []string
+	secret          string
+	active          bool
+	contentType     string
+	bodyTemplate    string
+	headersTemplate string
+}
+
+// addWebhookCreateFlags adds the flags of a create command. events lists the
+// events the webhook can subscribe to.
+func addWebhookCreateFlags(cmd *cobra.Command, f *webhookCreateFlags, events []string) {
+	cmd.Flags().StringSliceVarP(&f.events, "events", "e", nil, fmt.Sprintf("events to trigger the webhook, available events are (%s)", strings.Join(events, ", ")))
+	cmd.Flags().StringVarP(&f.secret, "secret", "s", "", "secret to sign the webhook payload, or the access token of matrix webhooks")
+	cmd.Flags().BoolVarP(&f.active, "active", "a", true, "whether the webhook is active")
+	cmd.Flags().StringVarP(&f.contentType, "content-type", "c", "json", "content type of the webhook payload, can be one of `json`, `form`, `slack`, `discord`, or `matrix`")
+	addWebhookTemplateFlags(cmd, &f.bodyTemplate, &f.headersTemplate)
+}
+
+// parse parses the events, content type, and templates of a new webhook.
+func (f *webhookCreateFlags) parse(cmd *cobra.Command, system bool) ([]webhook.Event, webhook.ContentType, webhook.Template, error) {
+	evs, err := parseWebhookEvents(f.events, system)
+	if err != nil {
+		return nil, 0, webhook.Template{}, err
+	}
+
+	ct, err := parseWebhookContentType(f.contentType)
+	if err != nil {
+		return nil, 0, webhook.Template{}, err
+	}
+
+	tmpl, err := webhookTemplate(cmd, webhook.Template{Body: f.bodyTemplate, Headers: f.headersTemplate})
+	if err != nil {
+		return nil, 0, webhook.Template{}, err
+	}
+
+	return evs, ct, tmpl, nil
+}
+
+// webhookUpdateFlags are the flags of the commands that update repository and
+// system webhooks. Flags that aren't set keep the current webhook values.
+type webhookUpdateFlags struct {
+	events          []string
+	secret          string
+	active          string
+	contentType     string
+	url             string
+	bodyTemplate    string
+	headersTemplate string
+}
+
+// addWebhookUpdateFlags adds the flags of an update command. events lists the
+// events the webhook can subscribe to.
+func addWebhookUpdateFlags(cmd *cobra.Command, f *webhookUpdateFlags, events []string) {
+	cmd.Flags().StringSliceVarP(&f.events, "events", "e", nil, fmt.Sprintf("events to trigger the webhook, available events are (%s)", strings.Join(events, ", ")))
+	cmd.Flags().StringVarP(&f.secret, "secret", "s", "", "secret to sign the webhook payload, or the access token of matrix webhooks")
+	cmd.Flags().StringVarP(&f.active, "active", "a", "", "whether the webhook is active")
+	cmd.Flags().StringVarP(&f.contentType, "content-type", "c", "", "content type of the webhook payload, can be one of `json`, `form`, `slack`, `discord`, or `matrix`")
+	cmd.Flags().StringVarP(&f.url, "url", "u", "", "webhook URL")
+	addWebhookTemplateFlags(cmd, &f.bodyTemplate, &f.headersTemplate)
+}
+
+// apply returns the webhook updated with the flags that were set, and its
+// template.
+func (f *webhookUpdateFlags) apply(cmd *cobra.Command, wh webhook.Hook, system bool) (webhook.Hook, webhook.Template, error) {
+	if f.url != "" {
+		wh.URL = f.url
+	}
+
+	if f.secret != "" {
+		wh.Secret = f.secret
+	}
+
+	if f.active != "" {
+		active, err := strconv.ParseBool(f.active)
+		if err != nil {
+			return wh, webhook.Template{}, fmt.Errorf("invalid active value: %w", err)
+		}
+
+		wh.Active = active
+	}
+
+	if f.contentType != "" {
+		ct, err := parseWebhookContentType(f.contentType)
+		if err != nil {
+			return wh, webhook.Template{}, err
+		}
+		wh.ContentType = ct
+	}
+
+	if len(f.events) > 0 {
+		evs, err := parseWebhookEvents(f.events, system)
+		if err != nil {
+			return wh, webhook.Template{}, err
+		}
+		wh.Events = evs
+	}
+
+	tmpl := webhook.Template{Body: wh.BodyTemplate, Headers: wh.HeadersTemplate}
+	if cmd.Flags().Changed("body-template") {
+		tmpl.Body = f.bodyTemplate
+	}
+	if cmd.Flags().Changed("headers-template") {
+		tmpl.Headers = f.headersTemplate
+	}
+
+	tmpl, err := webhookTemplate(cmd, tmpl)
+	return wh, tmpl, err
+}
+
+// addWebhookTemplateFlags adds the template flags of the create and update
+// commands.
+func addWebhookTemplateFlags(cmd *cobra.Command, body, headers *string) {
+	cmd.Flags().StringVar(body, "body-template", "", "Go template of the request body, use `-` to read it from stdin")
+	cmd.Flags().StringVar(headers, "headers-template", "", "Go template of extra request headers, one `Key: Value` per line, use `-` to read it from stdin")
+}
+
 func webhookListCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY",
@@ -60,28 +246,7 @@ func webhookListCommand() *cobra.Command {
 				return err
 			}
 
-			return tablewriter.Render(
-				cmd.OutOrStdout(),
-				webhooks,
-				[]string{"ID", "URL", "Events", "Active", "Created At", "Updated At"},
-				func(h webhook.Hook) ([]string, error) {
-					events := make([]string, len(h.Events))
-					for i, e := range h.Events {
-						events[i] = e.String()
-					}
-
-					row := []string{
-						strconv.FormatInt(h.ID, 10),
-						h.URL,
-						strings.Join(events, ","),
-						strconv.FormatBool(h.Active),
-						humanize.Time(h.CreatedAt),
-						humanize.Time(h.UpdatedAt),
-					}
-
-					return row, nil
-				},
-			)
+			return renderWebhooks(cmd, webhooks)
 		},
 	}
 
@@ -89,10 +254,7 @@ func webhookListCommand() *cobra.Command {
 }
 
 func webhookCreateCommand() *cobra.Command {
-	var events []string
-	var secret string
-	var active bool
-	var contentType string
+	var flags webhookCreateFlags
 	cmd := &cobra.Command{
 		Use:               "create REPOSITORY URL",
 		Short:             "Create a repository webhook",
@@ -106,34 +268,16 @@ func webhookCreateCommand() *cobra.Command {
 				return err
 			}
 
-			var evs []webhook.Event
-			for _, e := range events {
-				ev, err := webhook.ParseEvent(e)
-				if err != nil {
-					return fmt.Errorf("invalid event: %w", err)
-				}
-
-				evs = append(evs, ev)
-			}
-
-			var ct webhook.ContentType
-			switch strings.ToLower(strings.TrimSpace(contentType)) {
-			case "json":
-				ct = webhook.ContentTypeJSON
-			case "form":
-				ct = webhook.ContentTypeForm
-			default:
-				return webhook.ErrInvalidContentType
+			evs, ct, tmpl, err := flags.parse(cmd, false)
+			if err != nil {
+				return err
 			}
 
-			return be.CreateWebhook(ctx, repo, strings.TrimSpace(args[1]), ct, secret, evs, active)
+			return be.CreateWebhook(ctx, repo, strings.TrimSpace(args[1]), ct, flags.secret, evs, flags.active, tmpl)
 		},
 	}
 
-	cmd.Flags().StringSliceVarP(&events, "events", "e", nil, fmt.Sprintf("events to trigger the webhook, available events are (%s)", strings.Join(webhookEvents, ", ")))
-	cmd.Flags().StringVarP(&secret, "secret", "s", "", "secret to sign the webhook payload")
-	cmd.Flags().BoolVarP(&active, "active", "a", true, "whether the webhook is active")
-	cmd.Flags().StringVarP(&contentType, "content-type", "c", "json", "content type of the webhook payload, can be either `json` or `form`")
+	addWebhookCreateFlags(cmd, &flags, webhookEvents)
 
 	return cmd
 }
@@ -165,11 +309,7 @@ func webhookDeleteCommand() *cobra.Command {
 }
 
 func webhookUpdateCommand() *cobra.Command {
-	var events []string
-	var secret string
-	var active string
-	var contentType string
-	var url string
+	var flags webhookUpdateFlags
 	cmd := &cobra.Command{
 		Use:               "update REPOSITORY WEBHOOK_ID",
 		Short:             "Update a repository webhook",
@@ -193,65 +333,50 @@ func webhookUpdateCommand() *cobra.Command {
 				return err
 			}
 
-			newURL := wh.URL
-			if url != "" {
-				newURL = url
+			wh, tmpl, err := flags.apply(cmd, wh, false)
+			if err != nil {
+				return err
 			}
 
-			newSecret := wh.Secret
-			if secret != "" {
-				newSecret = secret
-			}
+			return be.UpdateWebhook(ctx, repo, id, wh.URL, wh.ContentType, wh.Secret, wh.Events, wh.Active, tmpl)
+		},
+	}
 
-			newActive := wh.Active
-			if active != "" {
-				active, err := strconv.ParseBool(active)
-				if err != nil {
-					return fmt.Errorf("invalid active value: %w", err)
-				}
+	addWebhookUpdateFlags(cmd, &flags, webhookEvents)
 
-				newActive = active
-			}
+	return cmd
+}
 
-			newContentType := wh.ContentType
-			if contentType != "" {
-				var ct webhook.ContentType
-				switch strings.ToLower(strings.TrimSpace(contentType)) {
-				case "json":
-					ct = webhook.ContentTypeJSON
-				case "form":
-					ct = webhook.ContentTypeForm
-				default:
-					return webhook.ErrInvalidContentType
-				}
-				newContentType = ct
+func webhookPingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "ping REPOSITORY WEBHOOK_ID",
+		Short:             "Send a ping event to a repository webhook",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
 			}
 
-			newEvents := wh.Events
-			if len(events) > 0 {
-				var evs []webhook.Event
-				for _, e := range events {
-					ev, err := webhook.ParseEvent(e)
-					if err != nil {
-						return fmt.Errorf("invalid event: %w", err)
-					}
-
-					evs = append(evs, ev)
-				}
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
 
-				newEvents = evs
+			del, err := be.PingWebhook(ctx, repo, id)
+			if err != nil {
+				return err
 			}
 
-			return be.UpdateWebhook(ctx, repo, id, newURL, newContentType, newSecret, newEvents, newActive)
+			printWebhookDelivery(cmd, del)
+
+			return nil
 		},
 	}
 
-	cmd.Flags().StringSliceVarP(&events, "events", "e", nil, fmt.Sprintf("events to trigger the webhook, available events are (%s)", strings.Join(webhookEvents, ", ")))
-	cmd.Flags().StringVarP(&secret, "secret", "s", "", "secret to sign the webhook payload")
-	cmd.Flags().StringVarP(&active, "active", "a", "", "whether the webhook is active")
-	cmd.Flags().StringVarP(&contentType, "content-type", "c", "", "content type of the webhook payload, can be either `json` or `form`")
-	cmd.Flags().StringVarP(&url, "url", "u", "", "webhook URL")
-
 	return cmd
 }
 
@@ -290,24 +415,7 @@ func webhookDeliveriesListCommand() *cobra.Command {
 				return err
 			}
 
-			return tablewriter.Render(
-				cmd.OutOrStdout(),
-				dels,
-				[]string{"Status", "ID", "Event", "Created At"},
-				func(d webhook.Delivery) ([]string, error) {
-					status := "❌"
-					if d.ResponseStatus >= 200 && d.ResponseStatus < 300 {
-						status = "✅"
-					}
-
-					return []string{
-						status,
-						d.ID.String(),
-						d.Event.String(),
-						humanize.Time(d.CreatedAt),
-					}, nil
-				},
-			)
+			return renderWebhookDeliveries(cmd, dels)
 		},
 	}
 
@@ -367,40 +475,108 @@ func webhookDeliveriesGetCommand() *cobra.Command {
 				return err
 			}
 
-			out := cmd.OutOrStdout()
-			fmt.Fprintf(out, "ID: %s\n", del.ID)
-			fmt.Fprintf(out, "Event: %s\n", del.Event)
-			fmt.Fprintf(out, "Request URL: %s\n", del.RequestURL)
-			fmt.Fprintf(out, "Request Method: %s\n", del.RequestMethod)
-			fmt.Fprintf(out, "Request Error: %s\n", del.RequestError.String)
-			fmt.Fprintf(out, "Request Headers:\n")
-			reqHeaders := strings.Split(del.RequestHeaders, "\n")
-			for _, h := range reqHeaders {
-				fmt.Fprintf(out, "  %s\n", h)
+			printWebhookDelivery(cmd, del)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// renderWebhooks renders a table of webhooks.
+func renderWebhooks(cmd *cobra.Command, webhooks []webhook.Hook) error {
+	return tablewriter.Render(
+		cmd.OutOrStdout(),
+		webhooks,
+		[]string{"ID", "URL", "Events", "Active", "Created At", "Updated At"},
+		func(h webhook.Hook) ([]string, error) {
+			events := make([]string, len(h.Events))
+			for i, e := range h.Events {
+				events[i] = e.String()
 			}
 
-			fmt.Fprintf(out, "Request Body:\n")
-			reqBody := strings.Split(del.RequestBody, "\n")
-			for _, b := range reqBody {
-				fmt.Fprintf(out, "  %s\n", b)
+			row := []string{
+				strconv.FormatInt(h.ID, 10),
+				h.URL,
+				strings.Join(events, ","),
+				strconv.FormatBool(h.Active),
+				humanize.Time(h.CreatedAt),
+				humanize.Time(h.UpdatedAt),
 			}
 
-			fmt.Fprintf(out, "Response Status: %d\n", del.ResponseStatus)
-			fmt.Fprintf(out, "Response Headers:\n")
-			resHeaders := strings.Split(del.ResponseHeaders, "\n")
-			for _, h := range resHeaders {
-				fmt.Fprintf(out, "  %s\n", h)
+			return row, nil
+		},
+	)
+}
+
+// renderWebhookDeliveries renders a table of webhook deliveries.
+func renderWebhookDeliveries(cmd *cobra.Command, dels []webhook.Delivery) error {
+	return tablewriter.Render(
+		cmd.OutOrStdout(),
+		dels,
+		[]string{"Status", "ID", "Event", "Attempts", "Next Attempt", "Created At"},
+		func(d webhook.Delivery) ([]string, error) {
+			status := "❌"
+			switch webhook.DeliveryStatus(d.Status) {
+			case webhook.DeliveryStatusDelivered:
+				status = "✅"
+			case webhook.DeliveryStatusPending:
+				status = "⏳"
 			}
 
-			fmt.Fprintf(out, "Response Body:\n")
-			resBody := strings.Split(del.ResponseBody, "\n")
-			for _, b := range resBody {
-				fmt.Fprintf(out, "  %s\n", b)
+			next := "-"
+			if d.NextAttemptAt.Valid {
+				next = humanize.Time(d.NextAttemptAt.Time)
 			}
 
-			return nil
+			return []string{
+				status,
+				d.ID.String(),
+				d.Event.String(),
+				strconv.Itoa(d.Attempts),
+				next,
+				humanize.Time(d.CreatedAt),
+			}, nil
 		},
+	)
+}
+
+// printWebhookDelivery prints the details of a webhook delivery.
+func printWebhookDelivery(cmd *cobra.Command, del webhook.Delivery) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "ID: %s\n", del.ID)
+	fmt.Fprintf(out, "Event: %s\n", del.Event)
+	fmt.Fprintf(out, "Status: %s\n", del.Status)
+	fmt.Fprintf(out, "Attempts: %d\n", del.Attempts)
+	if del.NextAttemptAt.Valid {
+		fmt.Fprintf(out, "Next Attempt: %s\n", del.NextAttemptAt.Time.Format(time.RFC3339))
+	}
+	fmt.Fprintf(out, "Request URL: %s\n", del.RequestURL)
+	fmt.Fprintf(out, "Request Method: %s\n", del.RequestMethod)
+	fmt.Fprintf(out, "Request Error: %s\n", del.RequestError.String)
+	fmt.Fprintf(out, "Request Headers:\n")
+	reqHeaders := strings.Split(del.RequestHeaders, "\n")
+	for _, h := range reqHeaders {
+		fmt.Fprintf(out, "  %s\n", h)
 	}
 
-	return cmd
+	fmt.Fprintf(out, "Request Body:\n")
+	reqBody := strings.Split(del.RequestBody, "\n")
+	for _, b := range reqBody {
+		fmt.Fprintf(out, "  %s\n", b)
+	}
+
+	fmt.Fprintf(out, "Response Status: %d\n", del.ResponseStatus)
+	fmt.Fprintf(out, "Response Headers:\n")
+	resHeaders := strings.Split(del.ResponseHeaders, "\n")
+	for _, h := range resHeaders {
+		fmt.Fprintf(out, "  %s\n", h)
+	}
+
+	fmt.Fprintf(out, "Response Body:\n")
+	resBody := strings.Split(del.ResponseBody, "\n")
+	for _, b := range resBody {
+		fmt.Fprintf(out, "  %s\n", b)
+	}
 }
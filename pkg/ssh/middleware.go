@@ -112,6 +112,8 @@ func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 			cmd.GitUploadArchiveCommand(),
 			cmd.GitReceivePackCommand(),
 			cmd.RepoCommand(renderer),
+			cmd.NotificationsCommand(),
+			cmd.SearchCommand(),
 			cmd.SettingsCommand(),
 			cmd.UserCommand(),
 			cmd.InfoCommand(),
@@ -121,6 +121,15 @@ func NewSSHServer(ctx context.Context) (*SSHServer, error) {
 		s.srv.IdleTimeout = time.Duration(cfg.SSH.IdleTimeout) * time.Second
 	}
 
+	// Drop connections from addresses denied by the global IP rules.
+	s.srv.ConnCallback = func(ctx ssh.Context, conn net.Conn) net.Conn {
+		if !be.AllowAddr(ctx, "", conn.RemoteAddr().String()) {
+			logger.Debug("connection denied", "addr", conn.RemoteAddr())
+			return nil
+		}
+		return conn
+	}
+
 	// Create client ssh key
 	if _, err := os.Stat(cfg.SSH.ClientKeyPath); err != nil && os.IsNotExist(err) {
 		_, err := keygen.New(cfg.SSH.ClientKeyPath, keygen.WithKeyType(keygen.Ed25519), keygen.WithWrite())
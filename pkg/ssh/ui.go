@@ -140,6 +140,7 @@ func (ui *UI) Init() tea.Cmd {
 		repo.NewLog(ui.common),
 		repo.NewRefs(ui.common, git.RefsHeads),
 		repo.NewRefs(ui.common, git.RefsTags),
+		repo.NewReleases(ui.common),
 	)
 	ui.SetSize(ui.common.Width, ui.common.Height)
 	cmds := make([]tea.Cmd, 0)
@@ -155,10 +156,10 @@ func (ui *UI) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-// IsFiltering returns true if the selection page is filtering.
+// IsFiltering returns true if the selection page is filtering or searching.
 func (ui *UI) IsFiltering() bool {
 	if ui.activePage == selectionPage {
-		if s, ok := ui.pages[selectionPage].(*selection.Selection); ok && s.FilterState() == list.Filtering {
+		if s, ok := ui.pages[selectionPage].(*selection.Selection); ok && (s.FilterState() == list.Filtering || s.IsSearching()) {
 			return true
 		}
 	}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// CIRunStore is an interface for managing CI runs.
+type CIRunStore interface {
+	// GetCIRunsByRepoID returns up to limit CI runs of a repository, newest
+	// first.
+	GetCIRunsByRepoID(ctx context.Context, h db.Handler, repoID int64, limit int) ([]models.CIRun, error)
+	// GetCIRunByID returns a CI run of a repository by its ID.
+	GetCIRunByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.CIRun, error)
+	// GetQueuedCIRuns returns up to limit queued CI runs, oldest first.
+	GetQueuedCIRuns(ctx context.Context, h db.Handler, limit int) ([]models.CIRun, error)
+	// QueueCIRun creates a queued CI run.
+	QueueCIRun(ctx context.Context, h db.Handler, repoID int64, userID int64, sha string, ref string) (int64, error)
+	// StartCIRun marks a queued CI run as running. It returns false when the
+	// run is not queued anymore.
+	StartCIRun(ctx context.Context, h db.Handler, id int64) (bool, error)
+	// FinishCIRun sets the final status of a CI run.
+	FinishCIRun(ctx context.Context, h db.Handler, id int64, status string) error
+}
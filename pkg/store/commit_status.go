@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// CommitStatusStore is an interface for managing commit statuses.
+type CommitStatusStore interface {
+	// GetCommitStatusesByRepoIDAndSHA returns the statuses of a commit.
+	GetCommitStatusesByRepoIDAndSHA(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.CommitStatus, error)
+	// GetCommitStatusesByRepoIDAndSHAs returns the statuses of multiple commits.
+	GetCommitStatusesByRepoIDAndSHAs(ctx context.Context, h db.Handler, repoID int64, shas []string) ([]models.CommitStatus, error)
+	// SetCommitStatus creates or updates the status of a commit for a context.
+	SetCommitStatus(ctx context.Context, h db.Handler, repoID int64, userID int64, sha string, context string, state string, targetURL string, description string) error
+}
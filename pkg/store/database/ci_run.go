@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type ciRunStore struct{}
+
+var _ store.CIRunStore = (*ciRunStore)(nil)
+
+// GetCIRunsByRepoID implements store.CIRunStore.
+func (*ciRunStore) GetCIRunsByRepoID(ctx context.Context, h db.Handler, repoID int64, limit int) ([]models.CIRun, error) {
+	var runs []models.CIRun
+	query := h.Rebind(`SELECT * FROM ci_runs WHERE repo_id = ? ORDER BY id DESC LIMIT ?;`)
+	err := h.SelectContext(ctx, &runs, query, repoID, limit)
+	return runs, err
+}
+
+// GetCIRunByID implements store.CIRunStore.
+func (*ciRunStore) GetCIRunByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.CIRun, error) {
+	var run models.CIRun
+	query := h.Rebind(`SELECT * FROM ci_runs WHERE repo_id = ? AND id = ?;`)
+	err := h.GetContext(ctx, &run, query, repoID, id)
+	return run, err
+}
+
+// GetQueuedCIRuns implements store.CIRunStore.
+func (*ciRunStore) GetQueuedCIRuns(ctx context.Context, h db.Handler, limit int) ([]models.CIRun, error) {
+	var runs []models.CIRun
+	query := h.Rebind(`SELECT * FROM ci_runs WHERE status = 'queued' ORDER BY id ASC LIMIT ?;`)
+	err := h.SelectContext(ctx, &runs, query, limit)
+	return runs, err
+}
+
+// QueueCIRun implements store.CIRunStore.
+func (*ciRunStore) QueueCIRun(ctx context.Context, h db.Handler, repoID int64, userID int64, sha string, ref string) (int64, error) {
+	var uid *int64
+	if userID > 0 {
+		uid = &userID
+	}
+
+	var id int64
+	query := h.Rebind(`INSERT INTO ci_runs (repo_id, user_id, commit_sha, ref, status, updated_at)
+			VALUES (?, ?, ?, ?, 'queued', CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, uid, sha, ref)
+	return id, err
+}
+
+// StartCIRun implements store.CIRunStore.
+func (*ciRunStore) StartCIRun(ctx context.Context, h db.Handler, id int64) (bool, error) {
+	query := h.Rebind(`UPDATE ci_runs SET status = 'running', started_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND status = 'queued';`)
+	res, err := h.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// FinishCIRun implements store.CIRunStore.
+func (*ciRunStore) FinishCIRun(ctx context.Context, h db.Handler, id int64, status string) error {
+	query := h.Rebind(`UPDATE ci_runs SET status = ?, finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, status, id)
+	return err
+}
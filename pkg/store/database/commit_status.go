@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/jmoiron/sqlx"
+)
+
+type commitStatusStore struct{}
+
+var _ store.CommitStatusStore = (*commitStatusStore)(nil)
+
+// GetCommitStatusesByRepoIDAndSHA implements store.CommitStatusStore.
+func (*commitStatusStore) GetCommitStatusesByRepoIDAndSHA(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.CommitStatus, error) {
+	var statuses []models.CommitStatus
+	query := h.Rebind(`SELECT * FROM commit_statuses WHERE repo_id = ? AND commit_sha = ? ORDER BY context ASC;`)
+	err := h.SelectContext(ctx, &statuses, query, repoID, sha)
+	return statuses, err
+}
+
+// GetCommitStatusesByRepoIDAndSHAs implements store.CommitStatusStore.
+func (*commitStatusStore) GetCommitStatusesByRepoIDAndSHAs(ctx context.Context, h db.Handler, repoID int64, shas []string) ([]models.CommitStatus, error) {
+	var statuses []models.CommitStatus
+	if len(shas) == 0 {
+		return statuses, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM commit_statuses WHERE repo_id = ? AND commit_sha IN (?) ORDER BY context ASC;`, repoID, shas)
+	if err != nil {
+		return nil, err
+	}
+
+	query = h.Rebind(query)
+	err = h.SelectContext(ctx, &statuses, query, args...)
+	return statuses, err
+}
+
+// SetCommitStatus implements store.CommitStatusStore.
+func (*commitStatusStore) SetCommitStatus(ctx context.Context, h db.Handler, repoID int64, userID int64, sha string, context string, state string, targetURL string, description string) error {
+	var uid *int64
+	if userID > 0 {
+		uid = &userID
+	}
+
+	query := h.Rebind(`INSERT INTO commit_statuses (repo_id, user_id, commit_sha, context, state, target_url, description, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id, commit_sha, context) DO UPDATE SET
+			user_id = excluded.user_id,
+			state = excluded.state,
+			target_url = excluded.target_url,
+			description = excluded.description,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, uid, sha, context, state, targetURL, description)
+	return err
+}
@@ -22,6 +22,15 @@ type datastore struct {
 	*lfsStore
 	*accessTokenStore
 	*webhookStore
+	*ipRuleStore
+	*releaseStore
+	*commitStatusStore
+	*gateRuleStore
+	*ciRunStore
+	*repoTrashStore
+	*repoTopicStore
+	*starStore
+	*notificationStore
 }
 
 // New returns a new store.Store database.
@@ -35,12 +44,21 @@ func New(ctx context.Context, db *db.DB) store.Store {
 		db:     db,
 		logger: logger,
 
-		settingsStore:    &settingsStore{},
-		repoStore:        &repoStore{},
-		userStore:        &userStore{},
-		collabStore:      &collabStore{},
-		lfsStore:         &lfsStore{},
-		accessTokenStore: &accessTokenStore{},
+		settingsStore:     &settingsStore{},
+		repoStore:         &repoStore{},
+		userStore:         &userStore{},
+		collabStore:       &collabStore{},
+		lfsStore:          &lfsStore{},
+		accessTokenStore:  &accessTokenStore{},
+		ipRuleStore:       &ipRuleStore{},
+		releaseStore:      &releaseStore{},
+		commitStatusStore: &commitStatusStore{},
+		gateRuleStore:     &gateRuleStore{},
+		ciRunStore:        &ciRunStore{},
+		repoTrashStore:    &repoTrashStore{},
+		repoTopicStore:    &repoTopicStore{},
+		starStore:         &starStore{},
+		notificationStore: &notificationStore{},
 	}
 
 	return s
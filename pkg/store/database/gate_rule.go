@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type gateRuleStore struct{}
+
+var _ store.GateRuleStore = (*gateRuleStore)(nil)
+
+// CreateGateRule implements store.GateRuleStore.
+func (*gateRuleStore) CreateGateRule(ctx context.Context, h db.Handler, repoID int64, branch string, url string, command string, secret string, timeout int64) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO repo_gate_rules (repo_id, branch, url, command, secret, timeout, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, branch, url, command, secret, timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteGateRuleForRepoByID implements store.GateRuleStore.
+func (*gateRuleStore) DeleteGateRuleForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM repo_gate_rules WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// GetGateRulesByRepoID implements store.GateRuleStore.
+func (*gateRuleStore) GetGateRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.GateRule, error) {
+	var rules []models.GateRule
+	query := h.Rebind(`SELECT * FROM repo_gate_rules WHERE repo_id = ? ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &rules, query, repoID)
+	return rules, err
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type ipRuleStore struct{}
+
+var _ store.IPRuleStore = (*ipRuleStore)(nil)
+
+// CreateIPRule implements store.IPRuleStore.
+func (*ipRuleStore) CreateIPRule(ctx context.Context, h db.Handler, repoID int64, cidr string, allow bool) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO repo_ip_rules (repo_id, cidr, allow, updated_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, cidr, allow)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteIPRuleForRepoByID implements store.IPRuleStore.
+func (*ipRuleStore) DeleteIPRuleForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM repo_ip_rules WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// GetIPRulesByRepoID implements store.IPRuleStore.
+func (*ipRuleStore) GetIPRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.IPRule, error) {
+	var rules []models.IPRule
+	query := h.Rebind(`SELECT * FROM repo_ip_rules WHERE repo_id = ? ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &rules, query, repoID)
+	return rules, err
+}
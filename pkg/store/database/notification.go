@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type notificationStore struct{}
+
+var _ store.NotificationStore = (*notificationStore)(nil)
+
+// CreateNotification implements store.NotificationStore.
+func (*notificationStore) CreateNotification(ctx context.Context, h db.Handler, userID int64, repoID int64, event int, message string) error {
+	query := h.Rebind(`INSERT INTO notifications (user_id, repo_id, event, message) VALUES (?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, event, message)
+	return db.WrapError(err)
+}
+
+// GetNotificationsByUserID implements store.NotificationStore.
+func (*notificationStore) GetNotificationsByUserID(ctx context.Context, h db.Handler, userID int64, all bool) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := `SELECT * FROM notifications WHERE user_id = ?`
+	if !all {
+		query += ` AND read = false`
+	}
+	query += ` ORDER BY id DESC;`
+	err := h.SelectContext(ctx, &notifications, h.Rebind(query), userID)
+	return notifications, db.WrapError(err)
+}
+
+// SetNotificationReadByID implements store.NotificationStore.
+func (*notificationStore) SetNotificationReadByID(ctx context.Context, h db.Handler, userID int64, id int64) error {
+	query := h.Rebind(`UPDATE notifications SET read = true WHERE user_id = ? AND id = ?;`)
+	res, err := h.ExecContext(ctx, query, userID, id)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return db.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SetNotificationsReadByUserID implements store.NotificationStore.
+func (*notificationStore) SetNotificationsReadByUserID(ctx context.Context, h db.Handler, userID int64) error {
+	query := h.Rebind(`UPDATE notifications SET read = true WHERE user_id = ?;`)
+	_, err := h.ExecContext(ctx, query, userID)
+	return db.WrapError(err)
+}
+
+// DeleteReadNotificationsByUserID implements store.NotificationStore.
+func (*notificationStore) DeleteReadNotificationsByUserID(ctx context.Context, h db.Handler, userID int64) error {
+	query := h.Rebind(`DELETE FROM notifications WHERE user_id = ? AND read = true;`)
+	_, err := h.ExecContext(ctx, query, userID)
+	return db.WrapError(err)
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type releaseStore struct{}
+
+var _ store.ReleaseStore = (*releaseStore)(nil)
+
+// CreateRelease implements store.ReleaseStore.
+func (*releaseStore) CreateRelease(ctx context.Context, h db.Handler, repoID int64, userID int64, tag string, title string, notes string) (int64, error) {
+	var id int64
+	var uid *int64
+	if userID > 0 {
+		uid = &userID
+	}
+
+	query := h.Rebind(`INSERT INTO releases (repo_id, user_id, tag, title, notes, updated_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, uid, tag, title, notes)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteReleaseByID implements store.ReleaseStore.
+func (*releaseStore) DeleteReleaseByID(ctx context.Context, h db.Handler, id int64) error {
+	query := h.Rebind(`DELETE FROM releases WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetReleaseByRepoIDAndTag implements store.ReleaseStore.
+func (*releaseStore) GetReleaseByRepoIDAndTag(ctx context.Context, h db.Handler, repoID int64, tag string) (models.Release, error) {
+	var release models.Release
+	query := h.Rebind(`SELECT * FROM releases WHERE repo_id = ? AND tag = ?;`)
+	err := h.GetContext(ctx, &release, query, repoID, tag)
+	return release, err
+}
+
+// GetReleasesByRepoID implements store.ReleaseStore.
+func (*releaseStore) GetReleasesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Release, error) {
+	var releases []models.Release
+	query := h.Rebind(`SELECT * FROM releases WHERE repo_id = ? ORDER BY created_at DESC, id DESC;`)
+	err := h.SelectContext(ctx, &releases, query, repoID)
+	return releases, err
+}
+
+// CreateReleaseAsset implements store.ReleaseStore.
+func (*releaseStore) CreateReleaseAsset(ctx context.Context, h db.Handler, releaseID int64, name string, size int64) error {
+	query := h.Rebind(`INSERT INTO release_assets (release_id, name, size, updated_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP);`)
+	_, err := h.ExecContext(ctx, query, releaseID, name, size)
+	return err
+}
+
+// GetReleaseAssetByName implements store.ReleaseStore.
+func (*releaseStore) GetReleaseAssetByName(ctx context.Context, h db.Handler, releaseID int64, name string) (models.ReleaseAsset, error) {
+	var asset models.ReleaseAsset
+	query := h.Rebind(`SELECT * FROM release_assets WHERE release_id = ? AND name = ?;`)
+	err := h.GetContext(ctx, &asset, query, releaseID, name)
+	return asset, err
+}
+
+// GetReleaseAssetsByReleaseID implements store.ReleaseStore.
+func (*releaseStore) GetReleaseAssetsByReleaseID(ctx context.Context, h db.Handler, releaseID int64) ([]models.ReleaseAsset, error) {
+	var assets []models.ReleaseAsset
+	query := h.Rebind(`SELECT * FROM release_assets WHERE release_id = ? ORDER BY name ASC;`)
+	err := h.SelectContext(ctx, &assets, query, releaseID)
+	return assets, err
+}
+
+// SetReleaseAssetSizeByID implements store.ReleaseStore.
+func (*releaseStore) SetReleaseAssetSizeByID(ctx context.Context, h db.Handler, id int64, size int64) error {
+	query := h.Rebind(`UPDATE release_assets SET size = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, size, id)
+	return err
+}
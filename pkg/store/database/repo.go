@@ -43,7 +43,7 @@ func (*repoStore) DeleteRepoByName(ctx context.Context, tx db.Handler, name stri
 // GetAllRepos implements store.RepositoryStore.
 func (*repoStore) GetAllRepos(ctx context.Context, tx db.Handler) ([]models.Repo, error) {
 	var repos []models.Repo
-	query := tx.Rebind("SELECT * FROM repos;")
+	query := tx.Rebind("SELECT * FROM repos WHERE id NOT IN (SELECT repo_id FROM repo_trash);")
 	err := tx.SelectContext(ctx, &repos, query)
 	return repos, db.WrapError(err)
 }
@@ -51,7 +51,7 @@ func (*repoStore) GetAllRepos(ctx context.Context, tx db.Handler) ([]models.Repo
 // GetUserRepos implements store.RepositoryStore.
 func (*repoStore) GetUserRepos(ctx context.Context, tx db.Handler, userID int64) ([]models.Repo, error) {
 	var repos []models.Repo
-	query := tx.Rebind("SELECT * FROM repos WHERE user_id = ?;")
+	query := tx.Rebind("SELECT * FROM repos WHERE user_id = ? AND id NOT IN (SELECT repo_id FROM repo_trash);")
 	err := tx.SelectContext(ctx, &repos, query, userID)
 	return repos, db.WrapError(err)
 }
@@ -65,6 +65,23 @@ func (*repoStore) GetRepoByName(ctx context.Context, tx db.Handler, name string)
 	return repo, db.WrapError(err)
 }
 
+// GetRepoByID implements store.RepositoryStore.
+func (*repoStore) GetRepoByID(ctx context.Context, tx db.Handler, id int64) (models.Repo, error) {
+	var repo models.Repo
+	query := tx.Rebind("SELECT * FROM repos WHERE id = ?;")
+	err := tx.GetContext(ctx, &repo, query, id)
+	return repo, db.WrapError(err)
+}
+
+// GetRepoDaemonPushByName implements store.RepositoryStore.
+func (*repoStore) GetRepoDaemonPushByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var daemonPush bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT daemon_push FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &daemonPush, query, name)
+	return daemonPush, db.WrapError(err)
+}
+
 // GetRepoDescriptionByName implements store.RepositoryStore.
 func (*repoStore) GetRepoDescriptionByName(ctx context.Context, tx db.Handler, name string) (string, error) {
 	var description string
@@ -74,6 +91,15 @@ func (*repoStore) GetRepoDescriptionByName(ctx context.Context, tx db.Handler, n
 	return description, db.WrapError(err)
 }
 
+// GetRepoIsArchivedByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsArchivedByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isArchived bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT archived FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isArchived, query, name)
+	return isArchived, db.WrapError(err)
+}
+
 // GetRepoIsHiddenByName implements store.RepositoryStore.
 func (*repoStore) GetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
 	var isHidden bool
@@ -101,6 +127,15 @@ func (*repoStore) GetRepoIsPrivateByName(ctx context.Context, tx db.Handler, nam
 	return isPrivate, db.WrapError(err)
 }
 
+// GetRepoIsTemplateByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsTemplateByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isTemplate bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT template FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isTemplate, query, name)
+	return isTemplate, db.WrapError(err)
+}
+
 // GetRepoProjectNameByName implements store.RepositoryStore.
 func (*repoStore) GetRepoProjectNameByName(ctx context.Context, tx db.Handler, name string) (string, error) {
 	var pname string
@@ -110,6 +145,14 @@ func (*repoStore) GetRepoProjectNameByName(ctx context.Context, tx db.Handler, n
 	return pname, db.WrapError(err)
 }
 
+// SetRepoDaemonPushByName implements store.RepositoryStore.
+func (*repoStore) SetRepoDaemonPushByName(ctx context.Context, tx db.Handler, name string, daemonPush bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET daemon_push = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, daemonPush, name)
+	return db.WrapError(err)
+}
+
 // SetRepoDescriptionByName implements store.RepositoryStore.
 func (*repoStore) SetRepoDescriptionByName(ctx context.Context, tx db.Handler, name string, description string) error {
 	name = utils.SanitizeRepo(name)
@@ -118,6 +161,14 @@ func (*repoStore) SetRepoDescriptionByName(ctx context.Context, tx db.Handler, n
 	return db.WrapError(err)
 }
 
+// SetRepoIsArchivedByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsArchivedByName(ctx context.Context, tx db.Handler, name string, isArchived bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET archived = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isArchived, name)
+	return db.WrapError(err)
+}
+
 // SetRepoIsHiddenByName implements store.RepositoryStore.
 func (*repoStore) SetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name string, isHidden bool) error {
 	name = utils.SanitizeRepo(name)
@@ -134,6 +185,14 @@ func (*repoStore) SetRepoIsPrivateByName(ctx context.Context, tx db.Handler, nam
 	return db.WrapError(err)
 }
 
+// SetRepoIsTemplateByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsTemplateByName(ctx context.Context, tx db.Handler, name string, isTemplate bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET template = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isTemplate, name)
+	return db.WrapError(err)
+}
+
 // SetRepoNameByName implements store.RepositoryStore.
 func (*repoStore) SetRepoNameByName(ctx context.Context, tx db.Handler, name string, newName string) error {
 	name = utils.SanitizeRepo(name)
@@ -150,3 +209,11 @@ func (*repoStore) SetRepoProjectNameByName(ctx context.Context, tx db.Handler, n
 	_, err := tx.ExecContext(ctx, query, projectName, name)
 	return db.WrapError(err)
 }
+
+// SetRepoUserIDByName implements store.RepositoryStore.
+func (*repoStore) SetRepoUserIDByName(ctx context.Context, tx db.Handler, name string, userID int64) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET user_id = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, userID, name)
+	return db.WrapError(err)
+}
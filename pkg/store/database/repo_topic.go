@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type repoTopicStore struct{}
+
+var _ store.RepoTopicStore = (*repoTopicStore)(nil)
+
+// GetRepoTopics implements store.RepoTopicStore.
+func (*repoTopicStore) GetRepoTopics(ctx context.Context, h db.Handler) ([]models.RepoTopic, error) {
+	var topics []models.RepoTopic
+	query := h.Rebind(`SELECT * FROM repo_topics ORDER BY topic ASC;`)
+	err := h.SelectContext(ctx, &topics, query)
+	return topics, db.WrapError(err)
+}
+
+// GetRepoTopicsByRepoID implements store.RepoTopicStore.
+func (*repoTopicStore) GetRepoTopicsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]string, error) {
+	var topics []string
+	query := h.Rebind(`SELECT topic FROM repo_topics WHERE repo_id = ? ORDER BY topic ASC;`)
+	err := h.SelectContext(ctx, &topics, query, repoID)
+	return topics, db.WrapError(err)
+}
+
+// AddRepoTopic implements store.RepoTopicStore.
+func (*repoTopicStore) AddRepoTopic(ctx context.Context, h db.Handler, repoID int64, topic string) error {
+	query := h.Rebind(`INSERT INTO repo_topics (repo_id, topic) VALUES (?, ?)
+		ON CONFLICT (repo_id, topic) DO NOTHING;`)
+	_, err := h.ExecContext(ctx, query, repoID, topic)
+	return db.WrapError(err)
+}
+
+// RemoveRepoTopic implements store.RepoTopicStore.
+func (*repoTopicStore) RemoveRepoTopic(ctx context.Context, h db.Handler, repoID int64, topic string) error {
+	query := h.Rebind(`DELETE FROM repo_topics WHERE repo_id = ? AND topic = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, topic)
+	return db.WrapError(err)
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type repoTrashStore struct{}
+
+var _ store.RepoTrashStore = (*repoTrashStore)(nil)
+
+// GetTrashedRepos implements store.RepoTrashStore.
+func (*repoTrashStore) GetTrashedRepos(ctx context.Context, h db.Handler) ([]models.TrashedRepo, error) {
+	var repos []models.TrashedRepo
+	query := h.Rebind(`SELECT * FROM repo_trash ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &repos, query)
+	return repos, db.WrapError(err)
+}
+
+// GetTrashedRepoByID implements store.RepoTrashStore.
+func (*repoTrashStore) GetTrashedRepoByID(ctx context.Context, h db.Handler, id int64) (models.TrashedRepo, error) {
+	var repo models.TrashedRepo
+	query := h.Rebind(`SELECT * FROM repo_trash WHERE id = ?;`)
+	err := h.GetContext(ctx, &repo, query, id)
+	return repo, db.WrapError(err)
+}
+
+// CreateTrashedRepo implements store.RepoTrashStore.
+func (*repoTrashStore) CreateTrashedRepo(ctx context.Context, h db.Handler, repoID int64, name string, userID int64) (int64, error) {
+	var uid *int64
+	if userID > 0 {
+		uid = &userID
+	}
+
+	var id int64
+	query := h.Rebind(`INSERT INTO repo_trash (repo_id, name, user_id) VALUES (?, ?, ?) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, name, uid)
+	return id, db.WrapError(err)
+}
+
+// DeleteTrashedRepoByID implements store.RepoTrashStore.
+func (*repoTrashStore) DeleteTrashedRepoByID(ctx context.Context, h db.Handler, id int64) error {
+	query := h.Rebind(`DELETE FROM repo_trash WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, id)
+	return db.WrapError(err)
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type starStore struct{}
+
+var _ store.StarStore = (*starStore)(nil)
+
+// StarRepo implements store.StarStore.
+func (*starStore) StarRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`INSERT INTO repo_stars (repo_id, user_id) VALUES (?, ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return db.WrapError(err)
+}
+
+// UnstarRepo implements store.StarStore.
+func (*starStore) UnstarRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`DELETE FROM repo_stars WHERE repo_id = ? AND user_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return db.WrapError(err)
+}
+
+// IsRepoStarred implements store.StarStore.
+func (*starStore) IsRepoStarred(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error) {
+	var count int64
+	query := h.Rebind(`SELECT COUNT(*) FROM repo_stars WHERE repo_id = ? AND user_id = ?;`)
+	err := h.GetContext(ctx, &count, query, repoID, userID)
+	return count > 0, db.WrapError(err)
+}
+
+// GetRepoStarCount implements store.StarStore.
+func (*starStore) GetRepoStarCount(ctx context.Context, h db.Handler, repoID int64) (int64, error) {
+	var count int64
+	query := h.Rebind(`SELECT COUNT(*) FROM repo_stars WHERE repo_id = ?;`)
+	err := h.GetContext(ctx, &count, query, repoID)
+	return count, db.WrapError(err)
+}
+
+// GetStarredReposByUserID implements store.StarStore.
+func (*starStore) GetStarredReposByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.Repo, error) {
+	var repos []models.Repo
+	query := h.Rebind(`
+		SELECT
+			repos.*
+		FROM
+			repos
+			INNER JOIN repo_stars ON repo_stars.repo_id = repos.id
+		WHERE
+			repo_stars.user_id = ?
+			AND repos.id NOT IN (SELECT repo_id FROM repo_trash)
+		ORDER BY
+			repo_stars.id DESC;
+	`)
+	err := h.SelectContext(ctx, &repos, query, userID)
+	return repos, db.WrapError(err)
+}
+
+// WatchRepo implements store.StarStore.
+func (*starStore) WatchRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`INSERT INTO repo_watches (repo_id, user_id) VALUES (?, ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return db.WrapError(err)
+}
+
+// UnwatchRepo implements store.StarStore.
+func (*starStore) UnwatchRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`DELETE FROM repo_watches WHERE repo_id = ? AND user_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return db.WrapError(err)
+}
+
+// IsRepoWatched implements store.StarStore.
+func (*starStore) IsRepoWatched(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error) {
+	var count int64
+	query := h.Rebind(`SELECT COUNT(*) FROM repo_watches WHERE repo_id = ? AND user_id = ?;`)
+	err := h.GetContext(ctx, &count, query, repoID, userID)
+	return count > 0, db.WrapError(err)
+}
+
+// GetRepoWatchers implements store.StarStore.
+func (*starStore) GetRepoWatchers(ctx context.Context, h db.Handler, repoID int64) ([]models.User, error) {
+	var users []models.User
+	query := h.Rebind(`SELECT users.*
+			FROM users
+			INNER JOIN repo_watches ON users.id = repo_watches.user_id
+			WHERE repo_watches.repo_id = ?
+			ORDER BY repo_watches.id ASC;`)
+	err := h.SelectContext(ctx, &users, query, repoID)
+	return users, db.WrapError(err)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -93,6 +94,17 @@ func (*webhookStore) GetWebhookByID(ctx context.Context, h db.Handler, repoID in
 	return wh, err
 }
 
+// GetWebhookByDeliveryID implements store.WebhookStore.
+func (*webhookStore) GetWebhookByDeliveryID(ctx context.Context, h db.Handler, id uuid.UUID) (models.Webhook, error) {
+	query := h.Rebind(`SELECT webhooks.*
+			FROM webhooks
+			INNER JOIN webhook_deliveries ON webhooks.id = webhook_deliveries.webhook_id
+			WHERE webhook_deliveries.id = ?;`)
+	var wh models.Webhook
+	err := h.GetContext(ctx, &wh, query, id)
+	return wh, err
+}
+
 // GetWebhookDeliveriesByWebhookID implements store.WebhookStore.
 func (*webhookStore) GetWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error) {
 	query := h.Rebind(`SELECT * FROM webhook_deliveries WHERE webhook_id = ?;`)
@@ -151,7 +163,8 @@ func (*webhookStore) GetWebhooksByRepoIDWhereEvent(ctx context.Context, h db.Han
 
 // ListWebhookDeliveriesByWebhookID implements store.WebhookStore.
 func (*webhookStore) ListWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error) {
-	query := h.Rebind(`SELECT id, response_status, event FROM webhook_deliveries WHERE webhook_id = ?;`)
+	query := h.Rebind(`SELECT id, response_status, event, status, attempts, next_attempt_at, created_at
+			FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at ASC;`)
 	var whds []models.WebhookDelivery
 	err := h.SelectContext(ctx, &whds, query, webhookID)
 	return whds, err
@@ -163,3 +176,115 @@ func (*webhookStore) UpdateWebhookByID(ctx context.Context, h db.Handler, repoID
 	_, err := h.ExecContext(ctx, query, url, secret, contentType, active, repoID, id)
 	return err
 }
+
+// UpdateWebhookTemplatesByID implements store.WebhookStore.
+func (*webhookStore) UpdateWebhookTemplatesByID(ctx context.Context, h db.Handler, repoID int64, id int64, bodyTemplate string, headersTemplate string) error {
+	query := h.Rebind(`UPDATE webhooks SET body_template = ?, headers_template = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, bodyTemplate, headersTemplate, repoID, id)
+	return err
+}
+
+// GetSystemWebhookByID implements store.WebhookStore.
+func (*webhookStore) GetSystemWebhookByID(ctx context.Context, h db.Handler, id int64) (models.Webhook, error) {
+	query := h.Rebind(`SELECT * FROM webhooks WHERE repo_id IS NULL AND id = ?;`)
+	var wh models.Webhook
+	err := h.GetContext(ctx, &wh, query, id)
+	return wh, err
+}
+
+// GetSystemWebhooks implements store.WebhookStore.
+func (*webhookStore) GetSystemWebhooks(ctx context.Context, h db.Handler) ([]models.Webhook, error) {
+	query := h.Rebind(`SELECT * FROM webhooks WHERE repo_id IS NULL;`)
+	var whs []models.Webhook
+	err := h.SelectContext(ctx, &whs, query)
+	return whs, err
+}
+
+// GetSystemWebhooksWhereEvent implements store.WebhookStore.
+func (*webhookStore) GetSystemWebhooksWhereEvent(ctx context.Context, h db.Handler, events []int) ([]models.Webhook, error) {
+	query, args, err := sqlx.In(`SELECT webhooks.*
+			FROM webhooks
+			INNER JOIN webhook_events ON webhooks.id = webhook_events.webhook_id
+			WHERE webhooks.repo_id IS NULL AND webhook_events.event IN (?);`, events)
+	if err != nil {
+		return nil, err
+	}
+
+	query = h.Rebind(query)
+	var whs []models.Webhook
+	err = h.SelectContext(ctx, &whs, query, args...)
+	return whs, err
+}
+
+// CreateSystemWebhook implements store.WebhookStore.
+func (*webhookStore) CreateSystemWebhook(ctx context.Context, h db.Handler, url string, secret string, contentType int, active bool) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO webhooks (url, secret, content_type, active, updated_at)
+			VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, url, secret, contentType, active)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateSystemWebhookByID implements store.WebhookStore.
+func (*webhookStore) UpdateSystemWebhookByID(ctx context.Context, h db.Handler, id int64, url string, secret string, contentType int, active bool) error {
+	query := h.Rebind(`UPDATE webhooks SET url = ?, secret = ?, content_type = ?, active = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id IS NULL AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, url, secret, contentType, active, id)
+	return err
+}
+
+// UpdateSystemWebhookTemplatesByID implements store.WebhookStore.
+func (*webhookStore) UpdateSystemWebhookTemplatesByID(ctx context.Context, h db.Handler, id int64, bodyTemplate string, headersTemplate string) error {
+	query := h.Rebind(`UPDATE webhooks SET body_template = ?, headers_template = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id IS NULL AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, bodyTemplate, headersTemplate, id)
+	return err
+}
+
+// QueueWebhookDelivery implements store.WebhookStore.
+func (*webhookStore) QueueWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestHeaders string, requestBody string, nextAttemptAt time.Time) error {
+	query := h.Rebind(`INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_headers, request_body, response_status, response_headers, response_body, status, attempts, next_attempt_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, 0, '', '', 'pending', 0, ?);`)
+	_, err := h.ExecContext(ctx, query, id, webhookID, event, url, method, requestHeaders, requestBody, nextAttemptAt.UTC())
+	return err
+}
+
+// GetDueWebhookDeliveries implements store.WebhookStore.
+func (*webhookStore) GetDueWebhookDeliveries(ctx context.Context, h db.Handler, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	query := h.Rebind(`SELECT * FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC LIMIT ?;`)
+	var whds []models.WebhookDelivery
+	err := h.SelectContext(ctx, &whds, query, now.UTC(), limit)
+	return whds, err
+}
+
+// ClaimWebhookDelivery implements store.WebhookStore.
+func (*webhookStore) ClaimWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, now time.Time, until time.Time) (bool, error) {
+	query := h.Rebind(`UPDATE webhook_deliveries SET next_attempt_at = ?
+			WHERE id = ? AND status = 'pending' AND next_attempt_at <= ?;`)
+	res, err := h.ExecContext(ctx, query, until.UTC(), id, now.UTC())
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// UpdateWebhookDeliveryAttempt implements store.WebhookStore.
+func (*webhookStore) UpdateWebhookDeliveryAttempt(ctx context.Context, h db.Handler, id uuid.UUID, status string, attempts int, nextAttemptAt *time.Time, requestError error, responseStatus int, responseHeaders string, responseBody string) error {
+	query := h.Rebind(`UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, request_error = ?, response_status = ?, response_headers = ?, response_body = ?
+			WHERE id = ?;`)
+	var reqErr string
+	if requestError != nil {
+		reqErr = requestError.Error()
+	}
+	var next interface{}
+	if nextAttemptAt != nil {
+		next = nextAttemptAt.UTC()
+	}
+	_, err := h.ExecContext(ctx, query, status, attempts, next, reqErr, responseStatus, responseHeaders, responseBody, id)
+	return err
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// GateRuleStore is an interface for managing repository gate rules.
+type GateRuleStore interface {
+	// GetGateRulesByRepoID returns all gate rules for a repository.
+	GetGateRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.GateRule, error)
+	// CreateGateRule creates a gate rule for a repository.
+	CreateGateRule(ctx context.Context, h db.Handler, repoID int64, branch string, url string, command string, secret string, timeout int64) (int64, error)
+	// DeleteGateRuleForRepoByID deletes a gate rule for a repository by its ID.
+	DeleteGateRuleForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// IPRuleStore is an interface for managing repository IP access rules.
+type IPRuleStore interface {
+	// GetIPRulesByRepoID returns all IP rules for a repository.
+	GetIPRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.IPRule, error)
+	// CreateIPRule creates an IP rule for a repository.
+	CreateIPRule(ctx context.Context, h db.Handler, repoID int64, cidr string, allow bool) (int64, error)
+	// DeleteIPRuleForRepoByID deletes an IP rule for a repository by its ID.
+	DeleteIPRuleForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error
+}
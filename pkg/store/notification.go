@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// NotificationStore is an interface for managing user notifications.
+type NotificationStore interface {
+	// CreateNotification creates a notification for a user.
+	CreateNotification(ctx context.Context, h db.Handler, userID int64, repoID int64, event int, message string) error
+	// GetNotificationsByUserID returns the notifications of a user, newest
+	// first. Read notifications are only returned when all is true.
+	GetNotificationsByUserID(ctx context.Context, h db.Handler, userID int64, all bool) ([]models.Notification, error)
+	// SetNotificationReadByID marks a notification of a user as read.
+	SetNotificationReadByID(ctx context.Context, h db.Handler, userID int64, id int64) error
+	// SetNotificationsReadByUserID marks all the notifications of a user as
+	// read.
+	SetNotificationsReadByUserID(ctx context.Context, h db.Handler, userID int64) error
+	// DeleteReadNotificationsByUserID deletes the read notifications of a
+	// user.
+	DeleteReadNotificationsByUserID(ctx context.Context, h db.Handler, userID int64) error
+}
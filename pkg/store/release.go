@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ReleaseStore is an interface for managing repository releases.
+type ReleaseStore interface {
+	// GetReleasesByRepoID returns all releases of a repository.
+	GetReleasesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Release, error)
+	// GetReleaseByRepoIDAndTag returns the release of a repository tag.
+	GetReleaseByRepoIDAndTag(ctx context.Context, h db.Handler, repoID int64, tag string) (models.Release, error)
+	// CreateRelease creates a release for a repository tag.
+	CreateRelease(ctx context.Context, h db.Handler, repoID int64, userID int64, tag string, title string, notes string) (int64, error)
+	// DeleteReleaseByID deletes a release and its assets by the release ID.
+	DeleteReleaseByID(ctx context.Context, h db.Handler, id int64) error
+
+	// GetReleaseAssetsByReleaseID returns all assets of a release.
+	GetReleaseAssetsByReleaseID(ctx context.Context, h db.Handler, releaseID int64) ([]models.ReleaseAsset, error)
+	// GetReleaseAssetByName returns a release asset by its name.
+	GetReleaseAssetByName(ctx context.Context, h db.Handler, releaseID int64, name string) (models.ReleaseAsset, error)
+	// CreateReleaseAsset creates a release asset.
+	CreateReleaseAsset(ctx context.Context, h db.Handler, releaseID int64, name string, size int64) error
+	// SetReleaseAssetSizeByID sets the size of a release asset.
+	SetReleaseAssetSizeByID(ctx context.Context, h db.Handler, id int64, size int64) error
+}
@@ -10,6 +10,7 @@ import (
 // RepositoryStore is an interface for managing repositories.
 type RepositoryStore interface {
 	GetRepoByName(ctx context.Context, h db.Handler, name string) (models.Repo, error)
+	GetRepoByID(ctx context.Context, h db.Handler, id int64) (models.Repo, error)
 	GetAllRepos(ctx context.Context, h db.Handler) ([]models.Repo, error)
 	GetUserRepos(ctx context.Context, h db.Handler, userID int64) ([]models.Repo, error)
 	CreateRepo(ctx context.Context, h db.Handler, name string, userID int64, projectName string, description string, isPrivate bool, isHidden bool, isMirror bool) error
@@ -25,4 +26,11 @@ type RepositoryStore interface {
 	GetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string) (bool, error)
 	SetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string, isHidden bool) error
 	GetRepoIsMirrorByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	GetRepoDaemonPushByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoDaemonPushByName(ctx context.Context, h db.Handler, name string, daemonPush bool) error
+	GetRepoIsArchivedByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsArchivedByName(ctx context.Context, h db.Handler, name string, isArchived bool) error
+	GetRepoIsTemplateByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsTemplateByName(ctx context.Context, h db.Handler, name string, isTemplate bool) error
+	SetRepoUserIDByName(ctx context.Context, h db.Handler, name string, userID int64) error
 }
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RepoTopicStore is an interface for managing repository topics.
+type RepoTopicStore interface {
+	// GetRepoTopics returns the topics of all repositories.
+	GetRepoTopics(ctx context.Context, h db.Handler) ([]models.RepoTopic, error)
+	// GetRepoTopicsByRepoID returns the topics of a repository, sorted by name.
+	GetRepoTopicsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]string, error)
+	// AddRepoTopic adds a topic to a repository. Adding a topic the repository
+	// already has is a no-op.
+	AddRepoTopic(ctx context.Context, h db.Handler, repoID int64, topic string) error
+	// RemoveRepoTopic removes a topic from a repository.
+	RemoveRepoTopic(ctx context.Context, h db.Handler, repoID int64, topic string) error
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RepoTrashStore is an interface for managing deleted repositories.
+type RepoTrashStore interface {
+	// GetTrashedRepos returns the trashed repositories, oldest first.
+	GetTrashedRepos(ctx context.Context, h db.Handler) ([]models.TrashedRepo, error)
+	// GetTrashedRepoByID returns a trashed repository by its ID.
+	GetTrashedRepoByID(ctx context.Context, h db.Handler, id int64) (models.TrashedRepo, error)
+	// CreateTrashedRepo records a repository as trashed by a user.
+	CreateTrashedRepo(ctx context.Context, h db.Handler, repoID int64, name string, userID int64) (int64, error)
+	// DeleteTrashedRepoByID removes a trashed repository record, leaving the
+	// repository in place.
+	DeleteTrashedRepoByID(ctx context.Context, h db.Handler, id int64) error
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// StarStore is an interface for managing repository stars and watches.
+type StarStore interface {
+	// StarRepo stars a repository for a user.
+	StarRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// UnstarRepo unstars a repository for a user.
+	UnstarRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// IsRepoStarred returns whether a user starred a repository.
+	IsRepoStarred(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error)
+	// GetRepoStarCount returns the number of users that starred a repository.
+	GetRepoStarCount(ctx context.Context, h db.Handler, repoID int64) (int64, error)
+	// GetStarredReposByUserID returns the repositories a user starred, most
+	// recently starred first.
+	GetStarredReposByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.Repo, error)
+
+	// WatchRepo watches a repository for a user.
+	WatchRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// UnwatchRepo unwatches a repository for a user.
+	UnwatchRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// IsRepoWatched returns whether a user watches a repository.
+	IsRepoWatched(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error)
+	// GetRepoWatchers returns the users watching a repository.
+	GetRepoWatchers(ctx context.Context, h db.Handler, repoID int64) ([]models.User, error)
+}
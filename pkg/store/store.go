@@ -9,4 +9,13 @@ type Store interface {
 	LFSStore
 	AccessTokenStore
 	WebhookStore
+	IPRuleStore
+	ReleaseStore
+	CommitStatusStore
+	GateRuleStore
+	CIRunStore
+	RepoTrashStore
+	RepoTopicStore
+	StarStore
+	NotificationStore
 }
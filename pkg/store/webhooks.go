@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -12,6 +13,8 @@ import (
 type WebhookStore interface {
 	// GetWebhookByID returns a webhook by its ID.
 	GetWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Webhook, error)
+	// GetWebhookByDeliveryID returns the webhook of a delivery.
+	GetWebhookByDeliveryID(ctx context.Context, h db.Handler, id uuid.UUID) (models.Webhook, error)
 	// GetWebhooksByRepoID returns all webhooks for a repository.
 	GetWebhooksByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Webhook, error)
 	// GetWebhooksByRepoIDWhereEvent returns all webhooks for a repository where event is in the events.
@@ -20,11 +23,26 @@ type WebhookStore interface {
 	CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, active bool) (int64, error)
 	// UpdateWebhookByID updates a webhook by its ID.
 	UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, active bool) error
+	// UpdateWebhookTemplatesByID updates the request templates of a repository webhook by its ID.
+	UpdateWebhookTemplatesByID(ctx context.Context, h db.Handler, repoID int64, id int64, bodyTemplate string, headersTemplate string) error
 	// DeleteWebhookByID deletes a webhook by its ID.
 	DeleteWebhookByID(ctx context.Context, h db.Handler, id int64) error
 	// DeleteWebhookForRepoByID deletes a webhook for a repository by its ID.
 	DeleteWebhookForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error
 
+	// GetSystemWebhookByID returns a system webhook by its ID.
+	GetSystemWebhookByID(ctx context.Context, h db.Handler, id int64) (models.Webhook, error)
+	// GetSystemWebhooks returns all system webhooks.
+	GetSystemWebhooks(ctx context.Context, h db.Handler) ([]models.Webhook, error)
+	// GetSystemWebhooksWhereEvent returns all system webhooks where event is in the events.
+	GetSystemWebhooksWhereEvent(ctx context.Context, h db.Handler, events []int) ([]models.Webhook, error)
+	// CreateSystemWebhook creates a system webhook.
+	CreateSystemWebhook(ctx context.Context, h db.Handler, url string, secret string, contentType int, active bool) (int64, error)
+	// UpdateSystemWebhookByID updates a system webhook by its ID.
+	UpdateSystemWebhookByID(ctx context.Context, h db.Handler, id int64, url string, secret string, contentType int, active bool) error
+	// UpdateSystemWebhookTemplatesByID updates the request templates of a system webhook by its ID.
+	UpdateSystemWebhookTemplatesByID(ctx context.Context, h db.Handler, id int64, bodyTemplate string, headersTemplate string) error
+
 	// GetWebhookEventByID returns a webhook event by its ID.
 	GetWebhookEventByID(ctx context.Context, h db.Handler, id int64) (models.WebhookEvent, error)
 	// GetWebhookEventsByWebhookID returns all webhook events for a webhook.
@@ -45,4 +63,15 @@ type WebhookStore interface {
 	CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string) error
 	// DeleteWebhookDeliveryByID deletes a webhook delivery by its ID.
 	DeleteWebhookDeliveryByID(ctx context.Context, h db.Handler, webhookID int64, id uuid.UUID) error
+	// QueueWebhookDelivery creates a pending webhook delivery to be sent at nextAttemptAt.
+	QueueWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestHeaders string, requestBody string, nextAttemptAt time.Time) error
+	// GetDueWebhookDeliveries returns up to limit pending webhook deliveries
+	// that are due at the given time, oldest first.
+	GetDueWebhookDeliveries(ctx context.Context, h db.Handler, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// ClaimWebhookDelivery reschedules a due pending webhook delivery at until
+	// and reports whether it was still due, so that only one server sends it.
+	ClaimWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, now time.Time, until time.Time) (bool, error)
+	// UpdateWebhookDeliveryAttempt records the result of a webhook delivery attempt.
+	// A nil nextAttemptAt means there are no more attempts scheduled.
+	UpdateWebhookDeliveryAttempt(ctx context.Context, h db.Handler, id uuid.UUID, status string, attempts int, nextAttemptAt *time.Time, requestError error, responseStatus int, responseHeaders string, responseBody string) error
 }
@@ -452,12 +452,23 @@ func (l *Log) updateCommitsCmd() tea.Msg {
 		l.common.Logger.Debugf("ui: error loading commits: %v", err)
 		return common.ErrorMsg(err)
 	}
+	states := map[string]proto.CommitStatusState{}
+	if be := l.common.Backend(); be != nil {
+		shas := make([]string, len(cc))
+		for i, c := range cc {
+			shas[i] = c.ID.String()
+		}
+		states, err = be.CommitStatusStates(l.common.Context(), l.repo.Name(), shas)
+		if err != nil {
+			l.common.Logger.Debugf("ui: error loading commit statuses: %v", err)
+		}
+	}
 	for i, c := range cc {
 		idx := i + skip
 		if int64(idx) >= count {
 			break
 		}
-		items[idx] = LogItem{Commit: c}
+		items[idx] = LogItem{Commit: c, State: states[c.ID.String()]}
 	}
 	return LogItemsMsg(items)
 }
@@ -11,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/muesli/reflow/truncate"
 )
@@ -18,6 +19,8 @@ import (
 // LogItem is a item in the log list that displays a git commit.
 type LogItem struct {
 	*git.Commit
+	// State is the combined status state of the commit, if any.
+	State proto.CommitStatusState
 }
 
 // ID implements selector.IdentifiableItem.
@@ -88,11 +91,14 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 
 	horizontalFrameSize := styles.Base.GetHorizontalFrameSize()
 
+	status := d.renderStatus(i.State)
+	statusWidth := lipgloss.Width(status)
 	hash := i.Commit.ID.String()[:7]
 	title := styles.Title.Render(
 		common.TruncateString(i.Title(),
 			m.Width()-
 				horizontalFrameSize-
+				statusWidth-
 				// 9 is the length of the hash (7) + the left padding (1) + the
 				// title truncation symbol (1)
 				9),
@@ -102,6 +108,7 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		PaddingLeft(1).
 		Width(m.Width() -
 			horizontalFrameSize -
+			statusWidth -
 			lipgloss.Width(title) - 1) // 1 is for the left padding
 	if index == m.Index() {
 		hashStyle = hashStyle.Bold(true)
@@ -109,6 +116,7 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	hash = hashStyle.Render(hash)
 	if m.Width()-horizontalFrameSize-hashStyle.GetHorizontalFrameSize()-hashStyle.GetWidth() <= 0 {
 		hash = ""
+		status = ""
 		title = styles.Title.Render(
 			common.TruncateString(i.Title(),
 				m.Width()-horizontalFrameSize),
@@ -135,9 +143,10 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 			i.ID(),
 			styles.Base.Render(
 				lipgloss.JoinVertical(lipgloss.Left,
-					truncate.String(fmt.Sprintf("%s%s",
+					truncate.String(fmt.Sprintf("%s%s%s",
 						title,
 						hash,
+						status,
 					), uint(m.Width()-horizontalFrameSize)),
 					who,
 				),
@@ -145,3 +154,18 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		),
 	)
 }
+
+// renderStatus renders the commit status indicator shown after the hash.
+func (d LogItemDelegate) renderStatus(state proto.CommitStatusState) string {
+	styles := d.common.Styles.LogItem.Status
+	switch state {
+	case proto.CommitStatusSuccess:
+		return styles.Success.Render(" ✓")
+	case proto.CommitStatusFailure, proto.CommitStatusError:
+		return styles.Failure.Render(" ✗")
+	case proto.CommitStatusPending:
+		return styles.Pending.Render(" ●")
+	default:
+		return ""
+	}
+}
@@ -225,6 +225,8 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, r.updateTabComponent(&Refs{refPrefix: msg.prefix}, msg))
 	case StashListMsg, StashPatchMsg:
 		cmds = append(cmds, r.updateTabComponent(&Stash{}, msg))
+	case ReleasesMsg, ReleaseMsg:
+		cmds = append(cmds, r.updateTabComponent(&Releases{}, msg))
 	// We have two spinners, one is used to when loading the repository and the
 	// other is used when loading the log.
 	// Check if the spinner ID matches the spinner model.
@@ -277,7 +279,7 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RepoMsg, RefMsg, tabs.ActiveTabMsg, tea.KeyMsg, tea.MouseMsg,
 		FileItemsMsg, FileContentMsg, FileBlameMsg, selector.ActiveMsg,
 		LogItemsMsg, GoBackMsg, LogDiffMsg, EmptyRepoMsg,
-		StashListMsg, StashPatchMsg:
+		StashListMsg, StashPatchMsg, ReleasesMsg, ReleaseMsg:
 		r.setStatusBarInfo()
 	}
 
@@ -332,6 +334,9 @@ func (r *Repo) headerView() string {
 		header = r.selectedRepo.Name()
 	}
 	header = r.common.Styles.Repo.HeaderName.Render(header)
+	if r.selectedRepo.IsArchived() {
+		header += r.common.Styles.Repo.HeaderDesc.Render(" (archived)")
+	}
 	desc := strings.TrimSpace(r.selectedRepo.Description())
 	if desc != "" {
 		header = lipgloss.JoinVertical(lipgloss.Left,
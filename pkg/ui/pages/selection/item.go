@@ -88,8 +88,11 @@ func (i Item) Title() string {
 // Description returns the item description. Implements list.DefaultItem.
 func (i Item) Description() string { return strings.TrimSpace(i.repo.Description()) }
 
-// FilterValue implements list.Item.
-func (i Item) FilterValue() string { return i.Title() }
+// FilterValue implements list.Item. Items are matched against their title
+// and topics.
+func (i Item) FilterValue() string {
+	return strings.Join(append([]string{i.Title()}, i.repo.Topics()...), " ")
+}
 
 // Command returns the item Command view.
 func (i Item) Command() string {
@@ -168,6 +171,9 @@ func (d *ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	if i.repo.IsPrivate() {
 		title += " 🔒"
 	}
+	if i.repo.IsArchived() {
+		title += " (archived)"
+	}
 	if isSelected {
 		title += " "
 	}
@@ -0,0 +1,179 @@
+package selection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/charmbracelet/soft-serve/pkg/ui/components/viewport"
+)
+
+// SearchResultsMsg is a message that contains code search results.
+type SearchResultsMsg struct {
+	Query   string
+	Results []backend.SearchResult
+	Err     error
+}
+
+// Search is the code search pane of the selection page.
+type Search struct {
+	common   common.Common
+	input    textinput.Model
+	viewport *viewport.Viewport
+	focusKey key.Binding
+	query    string
+	results  []backend.SearchResult
+	err      error
+}
+
+// NewSearch creates a new code search pane.
+func NewSearch(c common.Common) *Search {
+	input := textinput.New()
+	input.Prompt = "Search: "
+	input.PromptStyle = c.Styles.Search.Prompt
+	input.Placeholder = "code across repositories"
+	input.Focus()
+	s := &Search{
+		common:   c,
+		input:    input,
+		viewport: viewport.New(c),
+		focusKey: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+	}
+	s.viewport.SetContent(s.renderResults())
+	return s
+}
+
+// SetSize implements common.Component.
+func (s *Search) SetSize(width, height int) {
+	s.common.SetSize(width, height)
+	s.input.Width = width - lipgloss.Width(s.input.Prompt) - 1
+	// -2 for the input and the line after it
+	s.viewport.SetSize(width, height-2)
+	s.viewport.SetContent(s.renderResults())
+}
+
+// Focused returns whether the search input is focused.
+func (s *Search) Focused() bool {
+	return s.input.Focused()
+}
+
+// Init implements tea.Model.
+func (s *Search) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update implements tea.Model.
+func (s *Search) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+	switch msg := msg.(type) {
+	case SearchResultsMsg:
+		if msg.Query == s.query {
+			s.results, s.err = msg.Results, msg.Err
+			s.viewport.SetContent(s.renderResults())
+			s.viewport.GotoTop()
+		}
+	case tea.KeyMsg:
+		if s.input.Focused() {
+			switch {
+			case key.Matches(msg, s.common.KeyMap.Select):
+				s.query = strings.TrimSpace(s.input.Value())
+				if s.query != "" {
+					cmds = append(cmds, s.searchCmd(s.query))
+				}
+				s.input.Blur()
+			case key.Matches(msg, s.common.KeyMap.Back):
+				s.input.Blur()
+			case key.Matches(msg, s.common.KeyMap.Section):
+			default:
+				var cmd tea.Cmd
+				s.input, cmd = s.input.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return s, tea.Batch(cmds...)
+		}
+
+		if key.Matches(msg, s.focusKey) {
+			cmds = append(cmds, s.input.Focus())
+			return s, tea.Batch(cmds...)
+		}
+	}
+
+	v, cmd := s.viewport.Update(msg)
+	s.viewport = v.(*viewport.Viewport)
+	cmds = append(cmds, cmd)
+	return s, tea.Batch(cmds...)
+}
+
+// View implements tea.Model.
+func (s *Search) View() string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		s.input.View(),
+		"",
+		s.viewport.View(),
+	)
+}
+
+// ShortHelp returns the short help key bindings.
+func (s *Search) ShortHelp() []key.Binding {
+	if s.input.Focused() {
+		searchKey := s.common.KeyMap.Select
+		searchKey.SetHelp("enter", "search")
+		return []key.Binding{searchKey, s.common.KeyMap.Back}
+	}
+
+	return []key.Binding{s.common.KeyMap.UpDown, s.focusKey}
+}
+
+func (s *Search) searchCmd(query string) tea.Cmd {
+	ctx := s.common.Context()
+	be := s.common.Backend()
+	return func() tea.Msg {
+		var opts backend.SearchOptions
+		if sess := sshutils.SessionFromContext(ctx); sess != nil {
+			opts.Addr = sess.RemoteAddr().String()
+		}
+
+		results, err := be.Search(ctx, proto.UserFromContext(ctx), query, opts)
+		return SearchResultsMsg{Query: query, Results: results, Err: err}
+	}
+}
+
+func (s *Search) renderResults() string {
+	st := s.common.Styles.Search
+	switch {
+	case s.err != nil:
+		return s.common.Styles.NoContent.Render(s.err.Error())
+	case s.query == "":
+		return s.common.Styles.NoContent.Render("Type a query and press enter to search.")
+	case len(s.results) == 0:
+		return s.common.Styles.NoContent.Render(fmt.Sprintf("No results for %q.", s.query))
+	}
+
+	var b strings.Builder
+	var last string
+	for _, r := range s.results {
+		if file := r.Repo + "/" + r.Path; file != last {
+			if last != "" {
+				b.WriteString("\n")
+			}
+			b.WriteString(st.Path.Render(file) + "\n")
+			last = file
+		}
+
+		line := st.Line.Render(fmt.Sprintf("%4d", r.Line))
+		text := common.TruncateString(r.Text, s.common.Width-lipgloss.Width(line)-1)
+		b.WriteString(line + " " + text + "\n")
+	}
+
+	return b.String()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
@@ -24,14 +25,18 @@ type pane int
 
 const (
 	selectorPane pane = iota
+	starredPane
 	readmePane
+	searchPane
 	lastPane
 )
 
 func (p pane) String() string {
 	return []string{
 		"Repositories",
+		"Starred",
 		"About",
+		"Search",
 	}[p]
 }
 
@@ -40,6 +45,8 @@ type Selection struct {
 	common     common.Common
 	readme     *code.Code
 	selector   *selector.Selector
+	starred    *selector.Selector
+	search     *Search
 	activePane pane
 	tabs       *tabs.Tabs
 }
@@ -47,7 +54,7 @@ type Selection struct {
 // New creates a new selection model.
 func New(c common.Common) *Selection {
 	ts := make([]string, lastPane)
-	for i, b := range []pane{selectorPane, readmePane} {
+	for i, b := range []pane{selectorPane, starredPane, readmePane, searchPane} {
 		ts[i] = b.String()
 	}
 	t := tabs.New(c, ts)
@@ -65,23 +72,42 @@ func New(c common.Common) *Selection {
 	readme.UseGlamour = true
 	readme.NoContentStyle = c.Styles.NoContent.
 		SetString(defaultNoContent)
-	selector := selector.New(c,
-		[]selector.IdentifiableItem{},
-		NewItemDelegate(&c, &sel.activePane))
-	selector.SetShowTitle(false)
-	selector.SetShowHelp(false)
-	selector.SetShowStatusBar(false)
-	selector.DisableQuitKeybindings()
-	sel.selector = selector
+	sel.selector = newSelector(c, &sel.activePane)
+	sel.starred = newSelector(c, &sel.activePane)
 	sel.readme = readme
+	sel.search = NewSearch(c)
+	return sel
+}
+
+// newSelector creates a repository selector.
+func newSelector(c common.Common, activePane *pane) *selector.Selector {
+	sel := selector.New(c,
+		[]selector.IdentifiableItem{},
+		NewItemDelegate(&c, activePane))
+	sel.SetShowTitle(false)
+	sel.SetShowHelp(false)
+	sel.SetShowStatusBar(false)
+	sel.DisableQuitKeybindings()
 	return sel
 }
 
+// activeSelector returns the selector of the active pane, or nil if the
+// active pane isn't a repository list.
+func (s *Selection) activeSelector() *selector.Selector {
+	switch s.activePane {
+	case selectorPane:
+		return s.selector
+	case starredPane:
+		return s.starred
+	}
+	return nil
+}
+
 func (s *Selection) getMargins() (wm, hm int) {
 	wm = 0
 	hm = s.common.Styles.Tabs.GetVerticalFrameSize() +
 		s.common.Styles.Tabs.GetHeight()
-	if s.activePane == selectorPane && s.IsFiltering() {
+	if s.activeSelector() != nil && s.IsFiltering() {
 		// hide tabs when filtering
 		hm = 0
 	}
@@ -90,7 +116,10 @@ func (s *Selection) getMargins() (wm, hm int) {
 
 // FilterState returns the current filter state.
 func (s *Selection) FilterState() list.FilterState {
-	return s.selector.FilterState()
+	if sel := s.activeSelector(); sel != nil {
+		return sel.FilterState()
+	}
+	return list.Unfiltered
 }
 
 // SetSize implements common.Component.
@@ -99,7 +128,9 @@ func (s *Selection) SetSize(width, height int) {
 	wm, hm := s.getMargins()
 	s.tabs.SetSize(width, height-hm)
 	s.selector.SetSize(width-wm, height-hm)
+	s.starred.SetSize(width-wm, height-hm)
 	s.readme.SetSize(width-wm, height-hm-1) // -1 for readme status line
+	s.search.SetSize(width-wm, height-hm)
 }
 
 // IsFiltering returns true if the selector is currently filtering.
@@ -107,15 +138,20 @@ func (s *Selection) IsFiltering() bool {
 	return s.FilterState() == list.Filtering
 }
 
+// IsSearching returns true if the search input is focused.
+func (s *Selection) IsSearching() bool {
+	return s.activePane == searchPane && s.search.Focused()
+}
+
 // ShortHelp implements help.KeyMap.
 func (s *Selection) ShortHelp() []key.Binding {
-	k := s.selector.KeyMap
 	kb := make([]key.Binding, 0)
 	kb = append(kb,
 		s.common.KeyMap.UpDown,
 		s.common.KeyMap.Section,
 	)
-	if s.activePane == selectorPane {
+	if sel := s.activeSelector(); sel != nil {
+		k := sel.KeyMap
 		copyKey := s.common.KeyMap.Copy
 		copyKey.SetHelp("c", "copy command")
 		kb = append(kb,
@@ -125,6 +161,9 @@ func (s *Selection) ShortHelp() []key.Binding {
 			copyKey,
 		)
 	}
+	if s.activePane == searchPane {
+		kb = append(kb, s.search.ShortHelp()...)
+	}
 	return kb
 }
 
@@ -150,10 +189,10 @@ func (s *Selection) FullHelp() [][]key.Binding {
 			k.Down,
 			k.Up,
 		})
-	case selectorPane:
+	case selectorPane, starredPane:
 		copyKey := s.common.KeyMap.Copy
 		copyKey.SetHelp("c", "copy command")
-		k := s.selector.KeyMap
+		k := s.activeSelector().KeyMap
 		if !s.IsFiltering() {
 			b[0] = append(b[0],
 				s.common.KeyMap.Select,
@@ -176,6 +215,8 @@ func (s *Selection) FullHelp() [][]key.Binding {
 			k.CancelWhileFiltering,
 			k.AcceptWhileFiltering,
 		})
+	case searchPane:
+		b = append(b, s.search.ShortHelp())
 	}
 	return b
 }
@@ -228,9 +269,31 @@ func (s *Selection) Init() tea.Cmd {
 	for i, it := range sortedItems {
 		items[i] = it
 	}
+
+	// Starred repositories are listed most recently starred first.
+	starred, err := be.StarredRepositories(ctx, proto.UserFromContext(ctx))
+	if err != nil {
+		return common.ErrorCmd(err)
+	}
+	starredItems := make([]selector.IdentifiableItem, 0, len(starred))
+	for _, r := range starred {
+		if be.AccessLevelByPublicKey(ctx, r.Name(), pk) < access.ReadOnlyAccess {
+			continue
+		}
+		item, err := NewItem(s.common, r)
+		if err != nil {
+			s.common.Logger.Debugf("ui: failed to create item for %s: %v", r.Name(), err)
+			continue
+		}
+		starredItems = append(starredItems, item)
+	}
+
 	return tea.Batch(
 		s.selector.Init(),
 		s.selector.SetItems(items),
+		s.starred.Init(),
+		s.starred.SetItems(starredItems),
+		s.search.Init(),
 		readmeCmd,
 	)
 }
@@ -250,12 +313,17 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		m, cmd = s.starred.Update(msg)
+		s.starred = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	case tea.KeyMsg, tea.MouseMsg:
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
 			switch {
 			case key.Matches(msg, s.common.KeyMap.Back):
-				cmds = append(cmds, s.selector.Init())
+				cmds = append(cmds, s.selector.Init(), s.starred.Init())
 			}
 		}
 		t, cmd := s.tabs.Update(msg)
@@ -265,6 +333,11 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case tabs.ActiveTabMsg:
 		s.activePane = pane(msg)
+	case SearchResultsMsg:
+		// Results can arrive after switching to another pane.
+		m, cmd := s.search.Update(msg)
+		s.search = m.(*Search)
+		return s, cmd
 	}
 	switch s.activePane {
 	case readmePane:
@@ -279,6 +352,18 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+	case starredPane:
+		m, cmd := s.starred.Update(msg)
+		s.starred = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case searchPane:
+		m, cmd := s.search.Update(msg)
+		s.search = m.(*Search)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 	return s, tea.Batch(cmds...)
 }
@@ -288,11 +373,11 @@ func (s *Selection) View() string {
 	var view string
 	wm, hm := s.getMargins()
 	switch s.activePane {
-	case selectorPane:
+	case selectorPane, starredPane:
 		ss := s.common.Renderer.NewStyle().
 			Width(s.common.Width - wm).
 			Height(s.common.Height - hm)
-		view = ss.Render(s.selector.View())
+		view = ss.Render(s.activeSelector().View())
 	case readmePane:
 		rs := s.common.Renderer.NewStyle().
 			Height(s.common.Height - hm)
@@ -306,8 +391,13 @@ func (s *Selection) View() string {
 			s.readme.View(),
 			readmeStatus,
 		))
+	case searchPane:
+		ss := s.common.Renderer.NewStyle().
+			Width(s.common.Width - wm).
+			Height(s.common.Height - hm)
+		view = ss.Render(s.search.View())
 	}
-	if s.activePane != selectorPane || s.FilterState() != list.Filtering {
+	if s.FilterState() != list.Filtering {
 		tabs := s.common.Styles.Tabs.Render(s.tabs.View())
 		view = lipgloss.JoinVertical(lipgloss.Left,
 			tabs,
@@ -74,6 +74,11 @@ type Styles struct {
 			Desc    lipgloss.Style
 			Keyword lipgloss.Style
 		}
+		Status struct {
+			Success lipgloss.Style
+			Failure lipgloss.Style
+			Pending lipgloss.Style
+		}
 	}
 
 	Log struct {
@@ -141,6 +146,24 @@ type Styles struct {
 		Selector lipgloss.Style
 	}
 
+	Release struct {
+		Normal struct {
+			Title lipgloss.Style
+		}
+		Active struct {
+			Title lipgloss.Style
+		}
+		Tag      lipgloss.Style
+		Date     lipgloss.Style
+		Selector lipgloss.Style
+	}
+
+	Search struct {
+		Prompt lipgloss.Style
+		Path   lipgloss.Style
+		Line   lipgloss.Style
+	}
+
 	Spinner          lipgloss.Style
 	SpinnerContainer lipgloss.Style
 
@@ -341,6 +364,15 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 	s.LogItem.Active.Hash = r.NewStyle().
 		Foreground(highlightColor)
 
+	s.LogItem.Status.Success = r.NewStyle().
+		Foreground(lipgloss.Color("42"))
+
+	s.LogItem.Status.Failure = r.NewStyle().
+		Foreground(lipgloss.Color("203"))
+
+	s.LogItem.Status.Pending = r.NewStyle().
+		Foreground(lipgloss.Color("214"))
+
 	s.Log.Commit = r.NewStyle().
 		Margin(0, 2)
 
@@ -521,5 +553,30 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Width(1).
 		Foreground(selectorColor)
 
+	s.Release.Normal.Title = r.NewStyle().MarginLeft(1)
+
+	s.Release.Active.Title = s.Release.Normal.Title.Foreground(selectorColor)
+
+	s.Release.Tag = r.NewStyle().
+		Foreground(hashColor).
+		Bold(true)
+
+	s.Release.Date = r.NewStyle().
+		MarginLeft(1).
+		Foreground(lipgloss.Color("241"))
+
+	s.Release.Selector = r.NewStyle().
+		Width(1).
+		Foreground(selectorColor)
+
+	s.Search.Prompt = r.NewStyle().
+		Foreground(lipgloss.Color("36"))
+
+	s.Search.Path = r.NewStyle().
+		Foreground(hashColor).
+		Bold(true)
+
+	s.Search.Line = s.Code.LineDigit
+
 	return s
 }
@@ -50,3 +50,27 @@ func ValidateRepo(repo string) error {
 
 	return nil
 }
+
+// ValidateTopic returns an error if the given repository topic is invalid.
+// Topics are lowercase and up to 35 characters long.
+func ValidateTopic(topic string) error {
+	if topic == "" {
+		return fmt.Errorf("topic cannot be empty")
+	}
+
+	if len(topic) > 35 {
+		return fmt.Errorf("topic cannot be longer than 35 characters")
+	}
+
+	if topic[0] == '-' {
+		return fmt.Errorf("topic must start with a letter or number")
+	}
+
+	for _, r := range topic {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return fmt.Errorf("topic can only contain lowercase letters, numbers, and hyphens")
+		}
+	}
+
+	return nil
+}
@@ -54,3 +54,36 @@ func TestSanitizeRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTopic(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, topic := range []string{
+			"go",
+			"with-dash",
+			"3d",
+			"abcdefghijklmnopqrstuvwxyz012345678",
+		} {
+			t.Run(topic, func(t *testing.T) {
+				if err := ValidateTopic(topic); err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+			})
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, topic := range []string{
+			"",
+			"Upper",
+			"-dash",
+			"with space",
+			"with_underline",
+			"abcdefghijklmnopqrstuvwxyz0123456789",
+		} {
+			t.Run(topic, func(t *testing.T) {
+				if err := ValidateTopic(topic); err == nil {
+					t.Error("expected an error, got nil")
+				}
+			})
+		}
+	})
+}
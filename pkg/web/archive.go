@@ -0,0 +1,158 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	gitb "github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	//nolint:revive
+	gitHttpArchiveCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "http",
+		Name:      "git_archive_total",
+		Help:      "The total number of repository archive downloads",
+	}, []string{"repo", "format"})
+
+	//nolint:revive
+	gitHttpArchiveCacheHitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "http",
+		Name:      "git_archive_cache_hits_total",
+		Help:      "The total number of repository archives served from cache",
+	}, []string{"repo", "format"})
+)
+
+// archiveFormats are the supported archive formats ordered by file extension.
+var archiveFormats = []gitb.ArchiveFormat{
+	gitb.ArchiveTarGz,
+	gitb.ArchiveZip,
+}
+
+// getArchive serves an archive of a repository at the given ref.
+//
+// Archives of tags are cached on disk keyed by the commit they point to, so
+// that subsequent downloads don't have to regenerate them.
+func getArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx)
+	dir, repoName := mux.Vars(r)["dir"], mux.Vars(r)["repo"]
+
+	var ref string
+	var format gitb.ArchiveFormat
+	archive := mux.Vars(r)["archive"]
+	for _, f := range archiveFormats {
+		if strings.HasSuffix(archive, "."+string(f)) {
+			ref = strings.TrimSuffix(archive, "."+string(f))
+			format = f
+			break
+		}
+	}
+
+	if ref == "" || format == "" {
+		renderNotFound(w, r)
+		return
+	}
+
+	commit, err := gitb.ResolveCommit(ctx, dir, ref)
+	if err != nil {
+		renderNotFound(w, r)
+		return
+	}
+
+	gitHttpArchiveCounter.WithLabelValues(repoName, string(format)).Inc()
+
+	// Use the last path element of the repository name and the ref to name
+	// the top-level directory of the archive.
+	prefix := fmt.Sprintf("%s-%s", filepath.Base(repoName), strings.ReplaceAll(ref, "/", "-"))
+	filename := prefix + "." + string(format)
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	var isTag bool
+	if repo, err := gitb.Open(dir); err == nil {
+		isTag = repo.HasTag(ref)
+	}
+
+	if !isTag {
+		hdrNocache(w)
+		if err := gitb.Archive(ctx, dir, w, format, prefix, commit); err != nil {
+			logger.Error("failed to create archive", "repo", repoName, "ref", ref, "err", err)
+		}
+		return
+	}
+
+	cachePath := filepath.Join(cfg.DataPath, "cache", "archives", repoName+".git", commit, filename)
+	if _, err := os.Stat(cachePath); errors.Is(err, os.ErrNotExist) {
+		if err := writeArchiveCache(r, cachePath, dir, format, prefix, commit); err != nil {
+			logger.Error("failed to cache archive", "repo", repoName, "ref", ref, "err", err)
+			renderInternalServerError(w, r)
+			return
+		}
+	} else {
+		gitHttpArchiveCacheHitCounter.WithLabelValues(repoName, string(format)).Inc()
+	}
+
+	f, err := os.Open(cachePath)
+	if err != nil {
+		logger.Error("failed to open cached archive", "path", cachePath, "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	fi, err := f.Stat()
+	if err != nil {
+		logger.Error("failed to stat cached archive", "path", cachePath, "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	// Tags can be moved, so we only allow caching as long as the ETag, which
+	// is derived from the commit, matches.
+	w.Header().Set("ETag", fmt.Sprintf("%q", commit+"-"+string(format)))
+	w.Header().Set("Cache-Control", "public, no-cache")
+	http.ServeContent(w, r, filename, fi.ModTime(), f)
+}
+
+// writeArchiveCache writes an archive to the given cache path. The archive is
+// first written to a temporary file and then moved into place so that
+// concurrent requests never see a partial archive.
+func writeArchiveCache(r *http.Request, cachePath, dir string, format gitb.ArchiveFormat, prefix, commit string) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cachePath), ".archive-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+
+	if err := gitb.Archive(r.Context(), dir, tmp, format, prefix, commit); err != nil {
+		tmp.Close() // nolint: errcheck
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cachePath)
+}
@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -131,6 +130,14 @@ var gitRoutes = []GitRoute{
 		handler: getInfoRefs,
 		path:    "/info/refs",
 	},
+	// Commit statuses
+	// This must come before the dumb HTTP routes, otherwise a HEAD revision
+	// would be served as the repository HEAD file.
+	{
+		method:  []string{http.MethodGet, http.MethodPost},
+		handler: serviceCommitStatus,
+		path:    "/statuses/{rev:.+$}",
+	},
 	{
 		method:  []string{http.MethodGet},
 		handler: getTextFile,
@@ -156,6 +163,18 @@ var gitRoutes = []GitRoute{
 		handler: getIdxFile,
 		path:    "/objects/pack/{_:pack-[0-9a-f]{40}\\.idx$}",
 	},
+	// Archives
+	{
+		method:  []string{http.MethodGet},
+		handler: getArchive,
+		path:    "/archive/{archive:.+\\.(?:tar\\.gz|zip)$}",
+	},
+	// Releases
+	{
+		method:  []string{http.MethodGet},
+		handler: getReleaseAsset,
+		path:    "/releases/download/{tag:.+}/{asset:[^/]+$}",
+	},
 	// Git LFS
 	{
 		method:  []string{http.MethodPost},
@@ -208,6 +227,11 @@ func withAccess(next http.Handler) http.HandlerFunc {
 		// We're not checking for errors here because we want to allow
 		// repo creation on the fly.
 		repoName := mux.Vars(r)["repo"]
+		if !be.AllowAddr(ctx, repoName, r.RemoteAddr) {
+			renderForbidden(w, r)
+			return
+		}
+
 		repo, _ := be.Repository(ctx, repoName)
 		ctx = proto.WithRepositoryContext(ctx, repo)
 		r = r.WithContext(ctx)
@@ -260,6 +284,12 @@ func withAccess(next http.Handler) http.HandlerFunc {
 				return
 			}
 
+			// Archived repositories are read-only.
+			if repo != nil && repo.IsArchived() {
+				renderForbidden(w, r)
+				return
+			}
+
 			// Create the repo if it doesn't exist.
 			if repo == nil {
 				repo, err = be.CreateRepository(ctx, repoName, user, proto.RepositoryOptions{})
@@ -311,6 +341,14 @@ func withAccess(next http.Handler) http.HandlerFunc {
 						})
 						return
 					}
+
+					// Archived repositories don't accept new locks.
+					if strings.HasSuffix(file, "lfs/locks") && r.Method == http.MethodPost && repo != nil && repo.IsArchived() {
+						renderJSON(w, http.StatusForbidden, lfs.ErrorResponse{
+							Message: git.ErrArchived.Error(),
+						})
+						return
+					}
 				}
 			case strings.HasPrefix(file, "info/lfs/objects/basic"):
 				switch r.Method {
@@ -322,6 +360,13 @@ func withAccess(next http.Handler) http.HandlerFunc {
 						})
 						return
 					}
+
+					if repo != nil && repo.IsArchived() {
+						renderJSON(w, http.StatusForbidden, lfs.ErrorResponse{
+							Message: git.ErrArchived.Error(),
+						})
+						return
+					}
 				case http.MethodGet:
 					// Basic download
 				case http.MethodPost:
@@ -411,7 +456,7 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 	}
 	if len(version) != 0 {
 		cmd.Env = append(cmd.Env, []string{
-			fmt.Sprintf("GIT_PROTOCOL=%s", version),
+			fmt.Sprintf("%s=%s", git.ProtocolEnv, version),
 		}...)
 	}
 
@@ -512,24 +557,17 @@ func getInfoRefs(w http.ResponseWriter, r *http.Request) {
 			}...)
 		}
 		if len(protocol) != 0 {
-			cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_PROTOCOL=%s", protocol))
-		}
-
-		var version int
-		for _, p := range strings.Split(protocol, ":") {
-			if strings.HasPrefix(p, "version=") {
-				if v, _ := strconv.Atoi(p[8:]); v > version {
-					version = v
-				}
-			}
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", git.ProtocolEnv, protocol))
 		}
 
+		version := git.ProtocolVersion(protocol)
 		if err := service.Handler(ctx, cmd); err != nil {
 			renderNotFound(w, r)
 			return
 		}
 
 		hdrNocache(w)
+		w.Header().Set("Vary", "Git-Protocol")
 		w.Header().Set("Content-Type", fmt.Sprintf("application/x-%s-advertisement", service))
 		w.WriteHeader(http.StatusOK)
 		if version < 2 {
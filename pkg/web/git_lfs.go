@@ -19,6 +19,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/git"
 	"github.com/charmbracelet/soft-serve/pkg/lfs"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/storage"
@@ -187,6 +188,13 @@ func serviceLfsBatch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if repo.IsArchived() {
+			renderJSON(w, http.StatusForbidden, lfs.ErrorResponse{
+				Message: git.ErrArchived.Error(),
+			})
+			return
+		}
+
 		// Object upload logic happens in the "basic" API route
 		for _, o := range batchRequest.Objects {
 			if !o.IsValid() {
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	//nolint:revive
+	releaseDownloadCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soft_serve",
+		Subsystem: "http",
+		Name:      "release_asset_download_total",
+		Help:      "The total number of release asset downloads",
+	}, []string{"repo"})
+)
+
+// getReleaseAsset serves a release asset.
+func getReleaseAsset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+	be := backend.FromContext(ctx)
+	repoName, tag, name := mux.Vars(r)["repo"], mux.Vars(r)["tag"], mux.Vars(r)["asset"]
+
+	asset, obj, err := be.ReleaseAsset(ctx, repoName, tag, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, proto.ErrRepoNotFound),
+			errors.Is(err, proto.ErrReleaseNotFound),
+			errors.Is(err, proto.ErrReleaseAssetNotFound):
+			renderNotFound(w, r)
+		default:
+			logger.Error("failed to get release asset", "repo", repoName, "tag", tag, "asset", name, "err", err)
+			renderInternalServerError(w, r)
+		}
+		return
+	}
+
+	defer obj.Close() // nolint: errcheck
+
+	releaseDownloadCounter.WithLabelValues(repoName).Inc()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", asset.Name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	hdrNocache(w)
+	http.ServeContent(w, r, asset.Name, asset.UpdatedAt, obj)
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/search"
+	"github.com/gorilla/mux"
+)
+
+// searchResult is a code search result in API responses.
+type searchResult struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Text       string `json:"text"`
+}
+
+// searchResponse is the response of the code search endpoint.
+type searchResponse struct {
+	Query   string         `json:"query"`
+	Results []searchResult `json:"results"`
+}
+
+// SearchController registers the code search API routes.
+//
+// This must be registered before the git routes, otherwise the go-get route
+// would match the search path.
+func SearchController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/api/v1/search", serviceSearch).Methods(http.MethodGet)
+}
+
+// serviceSearch searches the code of the repositories the user can read.
+//
+// It takes the query in the q parameter, and optional repo, regex, and limit
+// parameters.
+func serviceSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+	be := backend.FromContext(ctx)
+
+	user, err := authenticate(r)
+	switch {
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrInvalidPassword):
+		writeJSON(w, http.StatusForbidden, apiErrorResponse{Message: "bad credentials"})
+		return
+	case user == nil && !be.AllowKeyless(ctx):
+		askCredentials(w, r)
+		writeJSON(w, http.StatusUnauthorized, apiErrorResponse{Message: "credentials needed"})
+		return
+	}
+
+	params := r.URL.Query()
+	query := params.Get("q")
+	if query == "" {
+		writeJSON(w, http.StatusBadRequest, apiErrorResponse{Message: "missing query"})
+		return
+	}
+
+	opts := backend.SearchOptions{
+		Repo: params.Get("repo"),
+		Addr: r.RemoteAddr,
+	}
+
+	if v := params.Get("regex"); v != "" {
+		opts.Regex, err = strconv.ParseBool(v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, apiErrorResponse{Message: "invalid regex parameter"})
+			return
+		}
+	}
+
+	if v := params.Get("limit"); v != "" {
+		opts.Limit, err = strconv.Atoi(v)
+		if err != nil || opts.Limit < 1 {
+			writeJSON(w, http.StatusBadRequest, apiErrorResponse{Message: "invalid limit parameter"})
+			return
+		}
+	}
+
+	results, err := be.Search(ctx, user, query, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, proto.ErrUnauthorized), errors.Is(err, proto.ErrRepoNotFound):
+			// Don't hint that the repo exists if the user doesn't have access
+			writeJSON(w, http.StatusNotFound, apiErrorResponse{Message: proto.ErrRepoNotFound.Error()})
+		case errors.Is(err, search.ErrEmptyQuery), errors.Is(err, search.ErrInvalidRegex):
+			writeJSON(w, http.StatusBadRequest, apiErrorResponse{Message: err.Error()})
+		default:
+			logger.Error("failed to search", "query", query, "err", err)
+			renderInternalServerError(w, r)
+		}
+		return
+	}
+
+	res := searchResponse{
+		Query:   query,
+		Results: make([]searchResult, len(results)),
+	}
+	for i, sr := range results {
+		res.Results[i] = searchResult{
+			Repository: sr.Repo,
+			Path:       sr.Path,
+			Line:       sr.Line,
+			Text:       sr.Text,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
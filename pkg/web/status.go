@@ -0,0 +1,142 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+	gitb "github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/gorilla/mux"
+)
+
+// commitStatus is a commit status in API responses.
+type commitStatus struct {
+	Context     string    `json:"context"`
+	State       string    `json:"state"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// commitStatusesResponse is the response of the commit statuses endpoint.
+type commitStatusesResponse struct {
+	// SHA is the resolved commit SHA.
+	SHA string `json:"sha"`
+	// State is the combined state of all statuses.
+	State    string         `json:"state"`
+	Statuses []commitStatus `json:"statuses"`
+}
+
+// commitStatusRequest is the request body to set a commit status.
+type commitStatusRequest struct {
+	State       string `json:"state"`
+	Context     string `json:"context"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+}
+
+// apiErrorResponse is an API error response.
+type apiErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func newCommitStatus(s models.CommitStatus) commitStatus {
+	return commitStatus{
+		Context:     s.Context,
+		State:       s.State,
+		TargetURL:   s.TargetURL,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// writeJSON writes a JSON API response.
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("error encoding json", "err", err)
+	}
+}
+
+// serviceCommitStatus lists the statuses of a commit, or sets the status of a
+// commit for a context. Setting a status requires write access to the
+// repository.
+func serviceCommitStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+	be := backend.FromContext(ctx)
+	repoName, rev := mux.Vars(r)["repo"], mux.Vars(r)["rev"]
+
+	if r.Method == http.MethodPost {
+		switch {
+		case proto.UserFromContext(ctx) == nil:
+			askCredentials(w, r)
+			writeJSON(w, http.StatusUnauthorized, apiErrorResponse{Message: "credentials needed"})
+			return
+		case access.FromContext(ctx) < access.ReadWriteAccess:
+			writeJSON(w, http.StatusForbidden, apiErrorResponse{Message: "write access required"})
+			return
+		}
+
+		var req commitStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, apiErrorResponse{Message: "invalid request body"})
+			return
+		}
+
+		state, err := proto.ParseCommitStatusState(req.State)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, apiErrorResponse{Message: err.Error()})
+			return
+		}
+
+		status, err := be.SetCommitStatus(ctx, repoName, rev, state, req.Context, req.TargetURL, req.Description)
+		if err != nil {
+			switch {
+			case errors.Is(err, gitb.ErrRevisionNotExist):
+				writeJSON(w, http.StatusNotFound, apiErrorResponse{Message: err.Error()})
+			default:
+				logger.Error("failed to set commit status", "repo", repoName, "rev", rev, "err", err)
+				renderInternalServerError(w, r)
+			}
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, newCommitStatus(status))
+		return
+	}
+
+	sha, statuses, err := be.CommitStatuses(ctx, repoName, rev)
+	if err != nil {
+		switch {
+		case errors.Is(err, gitb.ErrRevisionNotExist):
+			writeJSON(w, http.StatusNotFound, apiErrorResponse{Message: err.Error()})
+		default:
+			logger.Error("failed to get commit statuses", "repo", repoName, "rev", rev, "err", err)
+			renderInternalServerError(w, r)
+		}
+		return
+	}
+
+	res := commitStatusesResponse{
+		SHA:      sha,
+		Statuses: make([]commitStatus, len(statuses)),
+	}
+	states := make([]proto.CommitStatusState, len(statuses))
+	for i, s := range statuses {
+		res.Statuses[i] = newCommitStatus(s)
+		states[i] = proto.CommitStatusState(s.State)
+	}
+	res.State = string(proto.CombinedCommitStatusState(states...))
+
+	writeJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+// maxChatCommits is the maximum number of commits listed in a chat message.
+const maxChatCommits = 10
+
+// maxDiscordContent is the maximum length of a Discord message content.
+const maxDiscordContent = 2000
+
+// slackPayload is a Slack incoming webhook payload.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// discordPayload is a Discord webhook payload.
+type discordPayload struct {
+	Username string `json:"username"`
+	Content  string `json:"content"`
+}
+
+// matrixPayload is a Matrix m.room.message event content.
+type matrixPayload struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// chatFormatter formats parts of a chat message for a specific chat service.
+type chatFormatter interface {
+	// text escapes plain text.
+	text(s string) string
+	// link formats a link, text is unescaped.
+	link(text, url string) string
+	// code formats inline code, s is unescaped.
+	code(s string) string
+	// newline returns the line separator.
+	newline() string
+}
+
+type slackFormatter struct{}
+
+func (slackFormatter) text(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func (f slackFormatter) link(text, url string) string {
+	if url == "" {
+		return f.text(text)
+	}
+	return fmt.Sprintf("<%s|%s>", url, f.text(text))
+}
+
+func (f slackFormatter) code(s string) string { return "`" + f.text(s) + "`" }
+func (slackFormatter) newline() string        { return "\n" }
+
+type discordFormatter struct{}
+
+func (discordFormatter) text(s string) string { return s }
+
+func (discordFormatter) link(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+func (discordFormatter) code(s string) string { return "`" + strings.ReplaceAll(s, "`", "'") + "`" }
+func (discordFormatter) newline() string      { return "\n" }
+
+type plainFormatter struct{}
+
+func (plainFormatter) text(s string) string       { return s }
+func (plainFormatter) link(text, _ string) string { return text }
+func (plainFormatter) code(s string) string       { return s }
+func (plainFormatter) newline() string            { return "\n" }
+
+type htmlFormatter struct{}
+
+func (htmlFormatter) text(s string) string { return html.EscapeString(s) }
+
+func (htmlFormatter) link(text, url string) string {
+	if url == "" {
+		return html.EscapeString(text)
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+}
+
+func (htmlFormatter) code(s string) string { return "<code>" + html.EscapeString(s) + "</code>" }
+func (htmlFormatter) newline() string      { return "<br>" }
+
+// newChatPayload renders an event payload into a chat message payload of the
+// given content type.
+func newChatPayload(contentType ContentType, ev EventPayload) (interface{}, error) {
+	switch contentType {
+	case ContentTypeSlack:
+		return slackPayload{
+			Text: chatMessage(slackFormatter{}, ev),
+		}, nil
+	case ContentTypeDiscord:
+		content := chatMessage(discordFormatter{}, ev)
+		if len(content) > maxDiscordContent {
+			content = strings.ToValidUTF8(content[:maxDiscordContent-3], "") + "..."
+		}
+		return discordPayload{
+			Username: "Soft Serve",
+			Content:  content,
+		}, nil
+	case ContentTypeMatrix:
+		return matrixPayload{
+			MsgType:       "m.notice",
+			Body:          chatMessage(plainFormatter{}, ev),
+			Format:        "org.matrix.custom.html",
+			FormattedBody: chatMessage(htmlFormatter{}, ev),
+		}, nil
+	default:
+		return nil, ErrInvalidContentType
+	}
+}
+
+// encodeChatPayload renders and encodes a chat message payload. Chat messages
+// contain markup, so HTML characters are not escaped.
+func encodeChatPayload(contentType ContentType, payload EventPayload) ([]byte, error) {
+	p, err := newChatPayload(contentType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(p); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// chatMessage returns a human readable summary of an event.
+func chatMessage(f chatFormatter, payload EventPayload) string {
+	var repo Repository
+	var sender User
+	if c, ok := payload.(interface{ common() Common }); ok {
+		repo, sender = c.common().Repository, c.common().Sender
+	}
+
+	switch p := payload.(type) {
+	case UserEvent:
+		sender = p.Sender
+	case SettingsEvent:
+		sender = p.Sender
+	}
+
+	who := f.text(username(sender))
+	var prefix string
+	if repo.Name != "" {
+		prefix = "[" + f.link(repo.Name, repo.HTTPURL) + "] "
+	}
+
+	var lines []string
+	switch p := payload.(type) {
+	case PushEvent:
+		kind, name := refName(p.Ref)
+		noun := "commits"
+		if len(p.Commits) == 1 {
+			noun = "commit"
+		}
+		lines = append(lines, fmt.Sprintf("%s%s pushed %d %s to %s %s", prefix, who, len(p.Commits), noun, kind, f.code(name)))
+		for i, c := range p.Commits {
+			if i == maxChatCommits {
+				lines = append(lines, f.text(fmt.Sprintf("... and %d more", len(p.Commits)-maxChatCommits)))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("%s %s - %s", f.code(shortHash(c.ID)), f.text(c.Title), f.text(c.Author.Name)))
+		}
+	case BranchTagEvent:
+		kind, name := refName(p.Ref)
+		action := "created"
+		if p.Deleted {
+			action = "deleted"
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s %s %s", prefix, who, action, kind, f.code(name)))
+	case TagEvent:
+		action := "pushed"
+		switch {
+		case p.Created:
+			action = "created"
+		case p.Deleted:
+			action = "deleted"
+		}
+		line := fmt.Sprintf("%s%s %s tag %s", prefix, who, action, f.code(p.Tag.Name))
+		if p.Tag.Target != "" {
+			line += " at " + f.code(shortHash(p.Tag.Target))
+		}
+		lines = append(lines, line)
+		if title, _, _ := strings.Cut(strings.TrimSpace(p.Tag.Message), "\n"); title != "" {
+			lines = append(lines, f.text(title))
+		}
+	case PingEvent:
+		lines = append(lines, fmt.Sprintf("%s%s pinged webhook %s", prefix, who, f.code(fmt.Sprint(p.Hook.ID))))
+	case StatusEvent:
+		line := fmt.Sprintf("%s%s set %s to %s on %s", prefix, who, f.code(p.Context), f.text(p.State), f.code(shortHash(p.SHA)))
+		if p.TargetURL != "" {
+			line += " (" + f.link("details", p.TargetURL) + ")"
+		}
+		lines = append(lines, line)
+		if p.Description != "" {
+			lines = append(lines, f.text(p.Description))
+		}
+	case CollaboratorEvent:
+		collab := f.code(p.Collaborator.Username)
+		switch p.Action {
+		case CollaboratorEventAdded:
+			lines = append(lines, fmt.Sprintf("%s%s added collaborator %s with %s access", prefix, who, collab, f.text(p.AccessLevel.String())))
+		default:
+			lines = append(lines, fmt.Sprintf("%s%s removed collaborator %s", prefix, who, collab))
+		}
+	case RepositoryEvent:
+		switch p.Action {
+		case RepositoryEventActionCreate:
+			lines = append(lines, fmt.Sprintf("%s%s created the repository", prefix, who))
+		case RepositoryEventActionDelete:
+			lines = append(lines, fmt.Sprintf("%s%s deleted the repository", prefix, who))
+		case RepositoryEventActionRename:
+			lines = append(lines, fmt.Sprintf("%s%s renamed the repository", prefix, who))
+		case RepositoryEventActionVisibilityChange:
+			visibility := "public"
+			if repo.Private {
+				visibility = "private"
+			}
+			lines = append(lines, fmt.Sprintf("%s%s made the repository %s", prefix, who, visibility))
+		case RepositoryEventActionDefaultBranchChange:
+			lines = append(lines, fmt.Sprintf("%s%s changed the default branch to %s", prefix, who, f.code(repo.DefaultBranch)))
+		case RepositoryEventActionTransfer:
+			lines = append(lines, fmt.Sprintf("%s%s transferred the repository to %s", prefix, who, f.code(repo.Owner.Username)))
+		default:
+			lines = append(lines, fmt.Sprintf("%s%s updated the repository", prefix, who))
+		}
+	case ReleaseEvent:
+		lines = append(lines, fmt.Sprintf("%s%s %s release %s (%s)", prefix, who, f.text(string(p.Action)), f.text(p.Release.Title), f.code(p.Release.Tag)))
+		if p.Action != ReleaseEventActionDeleted {
+			for _, a := range p.Release.Assets {
+				lines = append(lines, f.link(a.Name, a.DownloadURL))
+			}
+		}
+	case UserEvent:
+		action := "created"
+		if p.Action == UserEventActionDelete {
+			action = "deleted"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s user %s", who, action, f.code(p.User.Username)))
+	case SettingsEvent:
+		lines = append(lines, fmt.Sprintf("%s set %s to %s", who, f.code(p.Setting), f.code(p.Value)))
+	default:
+		lines = append(lines, fmt.Sprintf("%s%s triggered %s", prefix, who, f.code(payload.Event().String())))
+	}
+
+	return strings.Join(lines, f.newline())
+}
+
+// refName returns the kind and short name of a reference.
+func refName(ref string) (kind string, name string) {
+	switch {
+	case strings.HasPrefix(ref, git.RefsHeads):
+		return "branch", strings.TrimPrefix(ref, git.RefsHeads)
+	case strings.HasPrefix(ref, git.RefsTags):
+		return "tag", strings.TrimPrefix(ref, git.RefsTags)
+	default:
+		return "ref", ref
+	}
+}
+
+// shortHash returns the abbreviated commit hash.
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+// username returns the username of an event user.
+func username(u User) string {
+	if u.Username == "" {
+		return "anonymous"
+	}
+	return u.Username
+}
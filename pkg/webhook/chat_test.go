@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func testPushEvent() PushEvent {
+	return PushEvent{
+		Common: Common{
+			EventType: EventPush,
+			Repository: Repository{
+				Name:    "repo1",
+				HTTPURL: "https://example.com/repo1.git",
+			},
+			Sender: User{Username: "alice"},
+		},
+		Ref: "refs/heads/main",
+		Commits: []Commit{
+			{ID: "0123456789abcdef", Title: "Fix <stuff>", Author: Author{Name: "Alice"}},
+		},
+	}
+}
+
+func TestChatPayload(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType ContentType
+		want        []string
+	}{
+		{
+			name:        "Slack",
+			contentType: ContentTypeSlack,
+			want: []string{
+				`"text":"[<https://example.com/repo1.git|repo1>] alice pushed 1 commit to branch ` + "`main`",
+				"`0123456`" + ` Fix &lt;stuff&gt; - Alice"`,
+			},
+		},
+		{
+			name:        "Discord",
+			contentType: ContentTypeDiscord,
+			want: []string{
+				`"username":"Soft Serve"`,
+				`"content":"[[repo1](https://example.com/repo1.git)] alice pushed 1 commit to branch ` + "`main`",
+			},
+		},
+		{
+			name:        "Matrix",
+			contentType: ContentTypeMatrix,
+			want: []string{
+				`"msgtype":"m.notice"`,
+				`"body":"[repo1] alice pushed 1 commit to branch main\n0123456 Fix <stuff> - Alice"`,
+				`<a href=\"https://example.com/repo1.git\">repo1</a>`,
+				`<code>0123456</code> Fix &lt;stuff&gt; - Alice`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := encodeChatPayload(tt.contentType, testPushEvent())
+			if err != nil {
+				t.Fatalf("encodeChatPayload() error = %v", err)
+			}
+
+			for _, w := range tt.want {
+				if !strings.Contains(string(b), w) {
+					t.Errorf("encodeChatPayload() = %s, want it to contain %s", b, w)
+				}
+			}
+		})
+	}
+}
+
+func TestChatMessageAnonymous(t *testing.T) {
+	ev := BranchTagEvent{
+		Common: Common{
+			EventType:  EventBranchTagCreate,
+			Repository: Repository{Name: "repo1"},
+		},
+		Ref:     "refs/tags/v1.0.0",
+		Created: true,
+	}
+
+	want := "[repo1] anonymous created tag v1.0.0"
+	if got := chatMessage(plainFormatter{}, ev); got != want {
+		t.Errorf("chatMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestChatMessageServerEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload EventPayload
+		want    string
+	}{
+		{
+			name: "UserCreate",
+			payload: UserEvent{
+				EventType: EventUser,
+				Action:    UserEventActionCreate,
+				User:      User{Username: "bob"},
+				Sender:    User{Username: "admin"},
+			},
+			want: "admin created user bob",
+		},
+		{
+			name: "UserDelete",
+			payload: UserEvent{
+				EventType: EventUser,
+				Action:    UserEventActionDelete,
+				User:      User{Username: "bob"},
+			},
+			want: "anonymous deleted user bob",
+		},
+		{
+			name: "Settings",
+			payload: SettingsEvent{
+				EventType: EventSettings,
+				Setting:   "anon-access",
+				Value:     "no-access",
+				Sender:    User{Username: "admin"},
+			},
+			want: "admin set anon-access to no-access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chatMessage(plainFormatter{}, tt.payload); got != tt.want {
+				t.Errorf("chatMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatMessageTag(t *testing.T) {
+	ev := TagEvent{
+		Common: Common{
+			EventType:  EventTag,
+			Repository: Repository{Name: "repo1"},
+			Sender:     User{Username: "alice"},
+		},
+		Ref:     "refs/tags/v1.0.0",
+		Created: true,
+		Tag: Tag{
+			Name:      "v1.0.0",
+			Annotated: true,
+			Target:    "0123456789abcdef",
+			Message:   "First release\n\nMore details\n",
+		},
+	}
+
+	want := "[repo1] alice created tag v1.0.0 at 0123456\nFirst release"
+	if got := chatMessage(plainFormatter{}, ev); got != want {
+		t.Errorf("chatMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestChatMessageStatus(t *testing.T) {
+	ev := StatusEvent{
+		Common: Common{
+			EventType:  EventStatus,
+			Repository: Repository{Name: "repo1"},
+			Sender:     User{Username: "ci"},
+		},
+		SHA:         "0123456789abcdef",
+		State:       "failure",
+		Context:     "ci/test",
+		TargetURL:   "https://ci.example.com/1",
+		Description: "2 tests failed",
+	}
+
+	want := "[repo1] ci set ci/test to failure on 0123456 (details)\n2 tests failed"
+	if got := chatMessage(plainFormatter{}, ev); got != want {
+		t.Errorf("chatMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestChatMessageRepositoryTransfer(t *testing.T) {
+	ev := RepositoryEvent{
+		Common: Common{
+			EventType: EventRepository,
+			Repository: Repository{
+				Name:  "repo1",
+				Owner: User{Username: "bob"},
+			},
+			Sender: User{Username: "alice"},
+		},
+		Action:        RepositoryEventActionTransfer,
+		PreviousOwner: &User{Username: "alice"},
+	}
+
+	want := "[repo1] alice transferred the repository to bob"
+	if got := chatMessage(plainFormatter{}, ev); got != want {
+		t.Errorf("chatMessage() = %q, want %q", got, want)
+	}
+}
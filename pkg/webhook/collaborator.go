@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/store"
@@ -48,13 +49,15 @@ func NewCollaboratorEvent(ctx context.Context, user proto.User, repo proto.Repos
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
-			Sender: User{
-				ID:       user.ID(),
-				Username: user.Username(),
-			},
+			Sender: newUser(user),
 		},
 	}
 
+	cfg := config.FromContext(ctx)
+	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	payload.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
 	// Find repo owner.
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
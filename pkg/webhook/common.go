@@ -1,6 +1,10 @@
 package webhook
 
-import "time"
+import (
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
 
 // EventPayload is a webhook event payload.
 type EventPayload interface {
@@ -32,6 +36,11 @@ func (c Common) RepositoryID() int64 {
 	return c.Repository.ID
 }
 
+// common returns the common payload of an event.
+func (c Common) common() Common {
+	return c
+}
+
 // User represents a user in an event.
 type User struct {
 	// ID is the owner ID.
@@ -40,6 +49,19 @@ type User struct {
 	Username string `json:"username" url:"username"`
 }
 
+// newUser returns the event user of the given user. Anonymous users, like
+// pushes over the Git daemon, have an empty event user.
+func newUser(user proto.User) User {
+	if user == nil {
+		return User{}
+	}
+
+	return User{
+		ID:       user.ID(),
+		Username: user.Username(),
+	}
+}
+
 // Repository represents an event repository.
 type Repository struct {
 	// ID is the repository ID.
@@ -92,4 +114,10 @@ type Commit struct {
 	Committer Author `json:"committer" url:"committer"`
 	// Timestamp is the commit timestamp.
 	Timestamp time.Time `json:"timestamp" url:"timestamp"`
+	// Added is the list of files added by the commit.
+	Added []string `json:"added" url:"added"`
+	// Modified is the list of files modified by the commit.
+	Modified []string `json:"modified" url:"modified"`
+	// Removed is the list of files removed by the commit.
+	Removed []string `json:"removed" url:"removed"`
 }
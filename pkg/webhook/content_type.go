@@ -14,11 +14,20 @@ const (
 	ContentTypeJSON ContentType = iota
 	// ContentTypeForm is the form content type.
 	ContentTypeForm
+	// ContentTypeSlack is a Slack compatible message payload.
+	ContentTypeSlack
+	// ContentTypeDiscord is a Discord compatible message payload.
+	ContentTypeDiscord
+	// ContentTypeMatrix is a Matrix message payload.
+	ContentTypeMatrix
 )
 
 var contentTypeStrings = map[ContentType]string{
-	ContentTypeJSON: "application/json",
-	ContentTypeForm: "application/x-www-form-urlencoded",
+	ContentTypeJSON:    "application/json",
+	ContentTypeForm:    "application/x-www-form-urlencoded",
+	ContentTypeSlack:   "slack",
+	ContentTypeDiscord: "discord",
+	ContentTypeMatrix:  "matrix",
 }
 
 // String returns the string representation of the content type.
@@ -26,9 +35,23 @@ func (c ContentType) String() string {
 	return contentTypeStrings[c]
 }
 
+// MediaType returns the media type used in the Content-Type header of the
+// webhook request. Chat message payloads are sent as JSON.
+func (c ContentType) MediaType() string {
+	switch c {
+	case ContentTypeSlack, ContentTypeDiscord, ContentTypeMatrix:
+		return contentTypeStrings[ContentTypeJSON]
+	default:
+		return contentTypeStrings[c]
+	}
+}
+
 var stringContentType = map[string]ContentType{
 	"application/json":                  ContentTypeJSON,
 	"application/x-www-form-urlencoded": ContentTypeForm,
+	"slack":                             ContentTypeSlack,
+	"discord":                           ContentTypeDiscord,
+	"matrix":                            ContentTypeMatrix,
 }
 
 // ErrInvalidContentType is returned when the content type is invalid.
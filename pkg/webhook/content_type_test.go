@@ -19,6 +19,11 @@ func TestParseContentType(t *testing.T) {
 			s:    "application/x-www-form-urlencoded",
 			want: ContentTypeForm,
 		},
+		{
+			name: "Slack",
+			s:    "slack",
+			want: ContentTypeSlack,
+		},
 		{
 			name: "Invalid",
 			s:    "application/invalid",
@@ -26,6 +26,25 @@ const (
 
 	// EventRepositoryVisibilityChange is a repository visibility change event.
 	EventRepositoryVisibilityChange Event = 6
+
+	// EventRelease is a release publish, update, delete event.
+	EventRelease Event = 7
+
+	// EventUser is a user create, delete server event.
+	EventUser Event = 8
+
+	// EventSettings is a server settings change event.
+	EventSettings Event = 9
+
+	// EventTag is a tag create, update, delete event.
+	EventTag Event = 10
+
+	// EventPing is a webhook test event. It is always sent on request and
+	// can't be subscribed to.
+	EventPing Event = 11
+
+	// EventStatus is a commit status event.
+	EventStatus Event = 12
 )
 
 // Events return all events.
@@ -37,9 +56,20 @@ func Events() []Event {
 		EventPush,
 		EventRepository,
 		EventRepositoryVisibilityChange,
+		EventRelease,
+		EventUser,
+		EventSettings,
+		EventTag,
+		EventStatus,
 	}
 }
 
+// IsServerEvent returns whether the event is a server event. Server events
+// aren't related to a repository and are only sent to system webhooks.
+func (e Event) IsServerEvent() bool {
+	return e == EventUser || e == EventSettings
+}
+
 var eventStrings = map[Event]string{
 	EventBranchTagCreate:            "branch_tag_create",
 	EventBranchTagDelete:            "branch_tag_delete",
@@ -47,6 +77,12 @@ var eventStrings = map[Event]string{
 	EventPush:                       "push",
 	EventRepository:                 "repository",
 	EventRepositoryVisibilityChange: "repository_visibility_change",
+	EventRelease:                    "release",
+	EventUser:                       "user",
+	EventSettings:                   "settings",
+	EventTag:                        "tag",
+	EventPing:                       "ping",
+	EventStatus:                     "status",
 }
 
 // String returns the string representation of the event.
@@ -61,6 +97,11 @@ var stringEvent = map[string]Event{
 	"push":                         EventPush,
 	"repository":                   EventRepository,
 	"repository_visibility_change": EventRepositoryVisibilityChange,
+	"release":                      EventRelease,
+	"user":                         EventUser,
+	"settings":                     EventSettings,
+	"tag":                          EventTag,
+	"status":                       EventStatus,
 }
 
 // ErrInvalidEvent is returned when the event is invalid.
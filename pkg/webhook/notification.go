@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+// NotifyWatchers notifies the users watching the repository of an event. The
+// user that triggered the event and the users canRead rejects, e.g. users
+// that can no longer read the repository, aren't notified.
+func NotifyWatchers(ctx context.Context, payload EventPayload, canRead func(repo string, user models.User) bool) error {
+	if payload.RepositoryID() == 0 || payload.Event() == EventPing {
+		return nil
+	}
+
+	c, ok := payload.(interface{ common() Common })
+	if !ok {
+		return nil
+	}
+
+	common := c.common()
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	watchers, err := datastore.GetRepoWatchers(ctx, dbx, common.Repository.ID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	if len(watchers) == 0 {
+		return nil
+	}
+
+	message := chatMessage(plainFormatter{}, payload)
+	for _, u := range watchers {
+		if u.ID == common.Sender.ID || !canRead(common.Repository.Name, u) {
+			continue
+		}
+
+		if err := datastore.CreateNotification(ctx, dbx, u.ID, common.Repository.ID, int(payload.Event()), message); err != nil {
+			return db.WrapError(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+// PingEvent is a synthetic event sent to test a webhook endpoint.
+type PingEvent struct {
+	Common
+
+	// Hook is the pinged webhook.
+	Hook PingHook `json:"hook" url:"hook"`
+}
+
+// PingHook represents the pinged webhook.
+type PingHook struct {
+	// ID is the webhook ID.
+	ID int64 `json:"id" url:"id"`
+	// URL is the webhook URL.
+	URL string `json:"url" url:"url"`
+	// Events are the events the webhook is subscribed to.
+	Events []string `json:"events" url:"events"`
+	// Active is whether the webhook is active.
+	Active bool `json:"active" url:"active"`
+}
+
+// NewPingEvent returns a ping event for the given webhook. The repository is
+// nil for system webhooks.
+func NewPingEvent(ctx context.Context, user proto.User, repo proto.Repository, h Hook) (PingEvent, error) {
+	payload := PingEvent{
+		Common: Common{
+			EventType: EventPing,
+			Sender:    newUser(user),
+		},
+		Hook: PingHook{
+			ID:     h.ID,
+			URL:    h.URL,
+			Events: make([]string, len(h.Events)),
+			Active: h.Active,
+		},
+	}
+
+	for i, e := range h.Events {
+		payload.Hook.Events[i] = e.String()
+	}
+
+	if repo == nil {
+		return payload, nil
+	}
+
+	payload.Repository = Repository{
+		ID:          repo.ID(),
+		Name:        repo.Name(),
+		Description: repo.Description(),
+		ProjectName: repo.ProjectName(),
+		Private:     repo.IsPrivate(),
+		CreatedAt:   repo.CreatedAt(),
+		UpdatedAt:   repo.UpdatedAt(),
+	}
+
+	cfg := config.FromContext(ctx)
+	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	payload.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
+	// Find repo owner.
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	owner, err := datastore.GetUserByID(ctx, dbx, repo.UserID())
+	if err != nil {
+		return PingEvent{}, db.WrapError(err)
+	}
+
+	payload.Repository.Owner.ID = owner.ID
+	payload.Repository.Owner.Username = owner.Username
+	payload.Repository.DefaultBranch, _ = getDefaultBranch(repo)
+
+	return payload, nil
+}
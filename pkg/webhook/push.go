@@ -45,10 +45,7 @@ func NewPushEvent(ctx context.Context, user proto.User, repo proto.Repository, r
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
-			Sender: User{
-				ID:       user.ID(),
-				Username: user.Username(),
-			},
+			Sender: newUser(user),
 		},
 	}
 
@@ -108,7 +105,25 @@ func NewPushEvent(ctx context.Context, user proto.User, repo proto.Repository, r
 			},
 			Timestamp: c.Committer.When,
 		}
+
+		changes, err := r.FileChanges(c)
+		if err != nil {
+			return PushEvent{}, err
+		}
+
+		payload.Commits[i].Added = nonNil(changes.Added)
+		payload.Commits[i].Modified = nonNil(changes.Modified)
+		payload.Commits[i].Removed = nonNil(changes.Removed)
 	}
 
 	return payload, nil
 }
+
+// nonNil returns an empty slice for nil slices so that they are encoded as
+// empty lists.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ReleaseEvent is a release event.
+type ReleaseEvent struct {
+	Common
+
+	// Action is the release event action.
+	Action ReleaseEventAction `json:"action" url:"action"`
+	// Release is the release.
+	Release Release `json:"release" url:"release"`
+}
+
+// ReleaseEventAction is a release event action.
+type ReleaseEventAction string
+
+const (
+	// ReleaseEventActionPublished is a release published event.
+	ReleaseEventActionPublished ReleaseEventAction = "published"
+	// ReleaseEventActionUpdated is a release updated event, e.g. when an
+	// asset is uploaded.
+	ReleaseEventActionUpdated ReleaseEventAction = "updated"
+	// ReleaseEventActionDeleted is a release deleted event.
+	ReleaseEventActionDeleted ReleaseEventAction = "deleted"
+)
+
+// Release represents a release in an event.
+type Release struct {
+	// ID is the release ID.
+	ID int64 `json:"id" url:"id"`
+	// Tag is the release tag name.
+	Tag string `json:"tag" url:"tag"`
+	// Title is the release title.
+	Title string `json:"title" url:"title"`
+	// Notes is the release notes in markdown.
+	Notes string `json:"notes" url:"notes"`
+	// Assets is the release assets.
+	Assets []ReleaseAsset `json:"assets" url:"assets"`
+	// CreatedAt is the release creation time.
+	CreatedAt time.Time `json:"created_at" url:"created_at"`
+}
+
+// ReleaseAsset represents a release asset in an event.
+type ReleaseAsset struct {
+	// Name is the asset file name.
+	Name string `json:"name" url:"name"`
+	// Size is the asset size in bytes.
+	Size int64 `json:"size" url:"size"`
+	// DownloadURL is the asset HTTP download URL.
+	DownloadURL string `json:"download_url" url:"download_url"`
+}
+
+// NewReleaseEvent sends a release event.
+func NewReleaseEvent(ctx context.Context, user proto.User, repo proto.Repository, release models.Release, assets []models.ReleaseAsset, action ReleaseEventAction) (ReleaseEvent, error) {
+	event := EventRelease
+
+	payload := ReleaseEvent{
+		Action: action,
+		Common: Common{
+			EventType: event,
+			Repository: Repository{
+				ID:          repo.ID(),
+				Name:        repo.Name(),
+				Description: repo.Description(),
+				ProjectName: repo.ProjectName(),
+				Private:     repo.IsPrivate(),
+				CreatedAt:   repo.CreatedAt(),
+				UpdatedAt:   repo.UpdatedAt(),
+			},
+			Sender: newUser(user),
+		},
+		Release: Release{
+			ID:        release.ID,
+			Tag:       release.Tag,
+			Title:     release.Title,
+			Notes:     release.Notes,
+			Assets:    make([]ReleaseAsset, 0, len(assets)),
+			CreatedAt: release.CreatedAt,
+		},
+	}
+
+	cfg := config.FromContext(ctx)
+	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	payload.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
+	for _, a := range assets {
+		payload.Release.Assets = append(payload.Release.Assets, ReleaseAsset{
+			Name:        a.Name,
+			Size:        a.Size,
+			DownloadURL: ReleaseAssetURL(cfg.HTTP.PublicURL, repo.Name(), release.Tag, a.Name),
+		})
+	}
+
+	// Find repo owner.
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	owner, err := datastore.GetUserByID(ctx, dbx, repo.UserID())
+	if err != nil {
+		return ReleaseEvent{}, db.WrapError(err)
+	}
+
+	payload.Repository.Owner.ID = owner.ID
+	payload.Repository.Owner.Username = owner.Username
+	payload.Repository.DefaultBranch, _ = getDefaultBranch(repo)
+
+	return payload, nil
+}
+
+// ReleaseAssetURL returns the HTTP download URL of a release asset.
+func ReleaseAssetURL(publicURL string, repo string, tag string, name string) string {
+	return fmt.Sprintf("%s/%s/releases/download/%s/%s", publicURL, utils.SanitizeRepo(repo),
+		url.PathEscape(tag), url.PathEscape(name))
+}
@@ -15,12 +15,16 @@ type RepositoryEvent struct {
 
 	// Action is the repository event action.
 	Action RepositoryEventAction `json:"action" url:"action"`
+	// PreviousOwner is the previous owner of a transferred repository.
+	PreviousOwner *User `json:"previous_owner,omitempty" url:"previous_owner,omitempty"`
 }
 
 // RepositoryEventAction is a repository event action.
 type RepositoryEventAction string
 
 const (
+	// RepositoryEventActionCreate is a repository created event.
+	RepositoryEventActionCreate RepositoryEventAction = "create"
 	// RepositoryEventActionDelete is a repository deleted event.
 	RepositoryEventActionDelete RepositoryEventAction = "delete"
 	// RepositoryEventActionRename is a repository renamed event.
@@ -29,6 +33,8 @@ const (
 	RepositoryEventActionVisibilityChange RepositoryEventAction = "visibility_change"
 	// RepositoryEventActionDefaultBranchChange is a repository default branch changed event.
 	RepositoryEventActionDefaultBranchChange RepositoryEventAction = "default_branch_change"
+	// RepositoryEventActionTransfer is a repository owner changed event.
+	RepositoryEventActionTransfer RepositoryEventAction = "transfer"
 )
 
 // NewRepositoryEvent sends a repository event.
@@ -54,10 +60,7 @@ func NewRepositoryEvent(ctx context.Context, user proto.User, repo proto.Reposit
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
-			Sender: User{
-				ID:       user.ID(),
-				Username: user.Username(),
-			},
+			Sender: newUser(user),
 		},
 	}
 
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// UserEvent is a user server event.
+type UserEvent struct {
+	// EventType is the event type.
+	EventType Event `json:"event" url:"event"`
+	// Action is the user event action.
+	Action UserEventAction `json:"action" url:"action"`
+	// User is the created or deleted user.
+	User User `json:"user" url:"user"`
+	// Sender is the sender payload.
+	Sender User `json:"sender" url:"sender"`
+}
+
+// UserEventAction is a user event action.
+type UserEventAction string
+
+const (
+	// UserEventActionCreate is a user created event.
+	UserEventActionCreate UserEventAction = "create"
+	// UserEventActionDelete is a user deleted event.
+	UserEventActionDelete UserEventAction = "delete"
+)
+
+// Event returns the event type.
+// Implements EventPayload.
+func (e UserEvent) Event() Event {
+	return e.EventType
+}
+
+// RepositoryID returns zero since user events don't belong to a repository.
+// Implements EventPayload.
+func (e UserEvent) RepositoryID() int64 {
+	return 0
+}
+
+// NewUserEvent returns a user server event.
+func NewUserEvent(_ context.Context, sender proto.User, user proto.User, action UserEventAction) (UserEvent, error) {
+	return UserEvent{
+		EventType: EventUser,
+		Action:    action,
+		User:      newUser(user),
+		Sender:    newUser(sender),
+	}, nil
+}
+
+// SettingsEvent is a server settings change event.
+type SettingsEvent struct {
+	// EventType is the event type.
+	EventType Event `json:"event" url:"event"`
+	// Setting is the name of the changed setting.
+	Setting string `json:"setting" url:"setting"`
+	// Value is the new value of the setting.
+	Value string `json:"value" url:"value"`
+	// Sender is the sender payload.
+	Sender User `json:"sender" url:"sender"`
+}
+
+// Event returns the event type.
+// Implements EventPayload.
+func (e SettingsEvent) Event() Event {
+	return e.EventType
+}
+
+// RepositoryID returns zero since settings events don't belong to a
+// repository.
+// Implements EventPayload.
+func (e SettingsEvent) RepositoryID() int64 {
+	return 0
+}
+
+// NewSettingsEvent returns a settings change server event.
+func NewSettingsEvent(_ context.Context, sender proto.User, setting string, value string) (SettingsEvent, error) {
+	return SettingsEvent{
+		EventType: EventSettings,
+		Setting:   setting,
+		Value:     value,
+		Sender:    newUser(sender),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+// StatusEvent is a commit status event.
+type StatusEvent struct {
+	Common
+
+	// SHA is the commit SHA.
+	SHA string `json:"sha" url:"sha"`
+	// State is the status state, one of pending, success, failure, or error.
+	State string `json:"state" url:"state"`
+	// Context is the status context, e.g. ci/build.
+	Context string `json:"context" url:"context"`
+	// TargetURL is the URL of the status details.
+	TargetURL string `json:"target_url" url:"target_url"`
+	// Description is the status description.
+	Description string `json:"description" url:"description"`
+	// CreatedAt is the status creation time.
+	CreatedAt time.Time `json:"created_at" url:"created_at"`
+	// UpdatedAt is the status update time.
+	UpdatedAt time.Time `json:"updated_at" url:"updated_at"`
+}
+
+// NewStatusEvent returns a commit status event.
+func NewStatusEvent(ctx context.Context, user proto.User, repo proto.Repository, status models.CommitStatus) (StatusEvent, error) {
+	payload := StatusEvent{
+		SHA:         status.CommitSHA,
+		State:       status.State,
+		Context:     status.Context,
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		CreatedAt:   status.CreatedAt,
+		UpdatedAt:   status.UpdatedAt,
+		Common: Common{
+			EventType: EventStatus,
+			Repository: Repository{
+				ID:          repo.ID(),
+				Name:        repo.Name(),
+				Description: repo.Description(),
+				ProjectName: repo.ProjectName(),
+				Private:     repo.IsPrivate(),
+				CreatedAt:   repo.CreatedAt(),
+				UpdatedAt:   repo.UpdatedAt(),
+			},
+			Sender: newUser(user),
+		},
+	}
+
+	cfg := config.FromContext(ctx)
+	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	payload.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
+	// Find repo owner.
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	owner, err := datastore.GetUserByID(ctx, dbx, repo.UserID())
+	if err != nil {
+		return StatusEvent{}, db.WrapError(err)
+	}
+
+	payload.Repository.Owner.ID = owner.ID
+	payload.Repository.Owner.Username = owner.Username
+	payload.Repository.DefaultBranch, _ = getDefaultBranch(repo)
+
+	return payload, nil
+}
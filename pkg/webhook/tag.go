@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+// TagEvent is a tag event.
+type TagEvent struct {
+	Common
+
+	// Ref is the tag reference name.
+	Ref string `json:"ref" url:"ref"`
+	// Before is the previous tag object SHA.
+	Before string `json:"before" url:"before"`
+	// After is the current tag object SHA.
+	After string `json:"after" url:"after"`
+	// Created is whether the tag was created.
+	Created bool `json:"created" url:"created"`
+	// Deleted is whether the tag was deleted.
+	Deleted bool `json:"deleted" url:"deleted"`
+	// Tag is the tag. It only has a name when the tag was deleted.
+	Tag Tag `json:"tag" url:"tag"`
+}
+
+// Tag represents a Git tag.
+type Tag struct {
+	// Name is the tag name.
+	Name string `json:"name" url:"name"`
+	// Annotated is whether the tag is an annotated tag.
+	Annotated bool `json:"annotated" url:"annotated"`
+	// Target is the SHA of the tagged commit.
+	Target string `json:"target" url:"target"`
+	// Message is the annotated tag message.
+	Message string `json:"message" url:"message"`
+	// Tagger is the annotated tag creator.
+	Tagger *Author `json:"tagger,omitempty" url:"tagger,omitempty"`
+	// Signature is the annotated tag signature status.
+	Signature TagSignature `json:"signature" url:"signature"`
+}
+
+// TagSignature is the signature status of a tag. Signatures are not verified
+// since the server doesn't hold any trusted keys.
+type TagSignature struct {
+	// Signed is whether the tag is signed.
+	Signed bool `json:"signed" url:"signed"`
+	// Format is the signature format, either gpg, ssh, or x509.
+	Format string `json:"format,omitempty" url:"format,omitempty"`
+}
+
+// NewTagEvent returns a tag event.
+func NewTagEvent(ctx context.Context, user proto.User, repo proto.Repository, ref, before, after string) (TagEvent, error) {
+	if !strings.HasPrefix(ref, git.RefsTags) {
+		return TagEvent{}, fmt.Errorf("invalid tag event: ref=%q", ref)
+	}
+
+	payload := TagEvent{
+		Ref:     ref,
+		Before:  before,
+		After:   after,
+		Created: git.IsZeroHash(before),
+		Deleted: git.IsZeroHash(after),
+		Tag: Tag{
+			Name: strings.TrimPrefix(ref, git.RefsTags),
+		},
+		Common: Common{
+			EventType: EventTag,
+			Repository: Repository{
+				ID:          repo.ID(),
+				Name:        repo.Name(),
+				Description: repo.Description(),
+				ProjectName: repo.ProjectName(),
+				Private:     repo.IsPrivate(),
+				CreatedAt:   repo.CreatedAt(),
+				UpdatedAt:   repo.UpdatedAt(),
+			},
+			Sender: newUser(user),
+		},
+	}
+
+	cfg := config.FromContext(ctx)
+	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	payload.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
+	// Find repo owner.
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	owner, err := datastore.GetUserByID(ctx, dbx, repo.UserID())
+	if err != nil {
+		return TagEvent{}, db.WrapError(err)
+	}
+
+	payload.Repository.Owner.ID = owner.ID
+	payload.Repository.Owner.Username = owner.Username
+	payload.Repository.DefaultBranch, _ = getDefaultBranch(repo)
+
+	if payload.Deleted {
+		return payload, nil
+	}
+
+	// Find the tag details. The reference might not be updated yet, so look
+	// up the tag object directly.
+	r, err := repo.Open()
+	if err != nil {
+		return TagEvent{}, err
+	}
+
+	tag, err := r.AnnotatedTag(after)
+	if err != nil {
+		return TagEvent{}, err
+	}
+
+	if tag == nil {
+		// Lightweight tag.
+		payload.Tag.Target = after
+		return payload, nil
+	}
+
+	payload.Tag.Annotated = true
+	payload.Tag.Target = tag.Target
+	payload.Tag.Message = tag.Message
+	payload.Tag.Signature = TagSignature{
+		Signed: tag.Signature != "",
+		Format: string(tag.Signature),
+	}
+	if tag.Tagger != nil {
+		payload.Tag.Tagger = &Author{
+			Name:  tag.Tagger.Name,
+			Email: tag.Tagger.Email,
+			Date:  tag.Tagger.When,
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+)
+
+// ErrInvalidTemplate is returned when a webhook template is invalid.
+var ErrInvalidTemplate = errors.New("invalid webhook template")
+
+// Template is a custom webhook request template.
+//
+// Both templates are Go text/template templates executed with the event
+// payload, e.g. PushEvent, as data. The body template replaces the encoded
+// payload, and the headers template renders extra request headers, one
+// "Key: Value" header per line.
+type Template struct {
+	// Body is the request body template.
+	Body string
+	// Headers is the request headers template.
+	Headers string
+}
+
+// templateFuncs are the functions available in webhook templates. Functions
+// that take a string to operate on accept it last so that they can be used
+// in pipelines.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+}
+
+// Validate validates the templates. The templates are rendered with a sample
+// payload of each of the given events so that unknown fields are reported
+// before an event is sent.
+func (t Template) Validate(events ...Event) error {
+	if _, err := parseTemplate("body", t.Body); err != nil {
+		return err
+	}
+	if _, err := parseTemplate("headers", t.Headers); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		payload := samplePayload(e)
+		if payload == nil {
+			continue
+		}
+
+		if _, err := t.renderBody(payload); err != nil {
+			return fmt.Errorf("%s event: %w", e, err)
+		}
+		if _, err := t.renderHeaders(payload); err != nil {
+			return fmt.Errorf("%s event: %w", e, err)
+		}
+	}
+
+	return nil
+}
+
+// renderBody renders the body template with the given payload.
+func (t Template) renderBody(payload interface{}) (string, error) {
+	return renderTemplate("body", t.Body, payload)
+}
+
+// renderHeaders renders the headers template with the given payload.
+func (t Template) renderHeaders(payload interface{}) (http.Header, error) {
+	s, err := renderTemplate("headers", t.Headers, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, ":")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" || strings.ContainsAny(k, " \t") {
+			return nil, fmt.Errorf("%w: invalid header %q", ErrInvalidTemplate, line)
+		}
+		if isReservedHeader(k) {
+			return nil, fmt.Errorf("%w: reserved header %q", ErrInvalidTemplate, k)
+		}
+
+		headers.Set(k, strings.TrimSpace(v))
+	}
+
+	return headers, nil
+}
+
+// isReservedHeader returns whether a request header is set by Soft Serve and
+// can't be set by templates.
+func isReservedHeader(k string) bool {
+	k = http.CanonicalHeaderKey(k)
+	return k == "Content-Type" || k == "User-Agent" || strings.HasPrefix(k, "X-Softserve-")
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+func renderTemplate(name, text string, data interface{}) (string, error) {
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return buf.String(), nil
+}
+
+// samplePayload returns a payload of the given event with sample data. It
+// returns nil for unknown events.
+func samplePayload(e Event) interface{} {
+	now := time.Now()
+	user := User{ID: 1, Username: "admin"}
+	author := Author{Name: "Admin", Email: "admin@example.com", Date: now}
+	common := Common{
+		EventType: e,
+		Repository: Repository{
+			ID:            1,
+			Name:          "repo",
+			ProjectName:   "Repo",
+			Description:   "A repository",
+			DefaultBranch: "main",
+			Owner:         user,
+			HTTPURL:       "http://localhost:23232/repo.git",
+			SSHURL:        "ssh://localhost:23231/repo.git",
+			GitURL:        "git://localhost:9418/repo.git",
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+		Sender: user,
+	}
+	sha := strings.Repeat("a", 40)
+	before := strings.Repeat("b", 40)
+
+	switch e {
+	case EventBranchTagCreate, EventBranchTagDelete:
+		return BranchTagEvent{Common: common, Ref: "refs/heads/main", Before: before, After: sha, Created: true}
+	case EventCollaborator:
+		return CollaboratorEvent{Common: common, Action: CollaboratorEventAdded, AccessLevel: access.ReadWriteAccess, Collaborator: user}
+	case EventPush:
+		return PushEvent{Common: common, Ref: "refs/heads/main", Before: before, After: sha, Commits: []Commit{
+			{
+				ID:        sha,
+				Message:   "Update README",
+				Title:     "Update README",
+				Author:    author,
+				Committer: author,
+				Timestamp: now,
+				Added:     []string{"LICENSE"},
+				Modified:  []string{"README.md"},
+				Removed:   []string{"TODO"},
+			},
+		}}
+	case EventRepository, EventRepositoryVisibilityChange:
+		return RepositoryEvent{Common: common, Action: RepositoryEventActionCreate, PreviousOwner: &user}
+	case EventRelease:
+		return ReleaseEvent{Common: common, Action: ReleaseEventActionPublished, Release: Release{
+			ID:        1,
+			Tag:       "v1.0.0",
+			Title:     "v1.0.0",
+			Notes:     "First release",
+			Assets:    []ReleaseAsset{{Name: "repo.tar.gz", Size: 1, DownloadURL: ReleaseAssetURL("http://localhost:23232", "repo", "v1.0.0", "repo.tar.gz")}},
+			CreatedAt: now,
+		}}
+	case EventUser:
+		return UserEvent{EventType: e, Action: UserEventActionCreate, User: user, Sender: user}
+	case EventSettings:
+		return SettingsEvent{EventType: e, Setting: "anon-access", Value: "read-only", Sender: user}
+	case EventTag:
+		return TagEvent{Common: common, Ref: "refs/tags/v1.0.0", Before: before, After: sha, Created: true, Tag: Tag{
+			Name:      "v1.0.0",
+			Annotated: true,
+			Target:    sha,
+			Message:   "v1.0.0",
+			Tagger:    &author,
+		}}
+	case EventStatus:
+		return StatusEvent{Common: common, SHA: sha, State: "success", Context: "ci", TargetURL: "http://localhost/ci/1", Description: "passed", CreatedAt: now, UpdatedAt: now}
+	}
+
+	return nil
+}
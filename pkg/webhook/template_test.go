@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   Template
+		events []Event
+		err    error
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Valid",
+			tmpl: Template{
+				Body:    `{"ref": {{ .Ref | trimPrefix "refs/heads/" | json }}}`,
+				Headers: "X-Repo: {{ .Repository.Name }}",
+			},
+		},
+		{
+			name: "InvalidBody",
+			tmpl: Template{Body: "{{ .Ref }"},
+			err:  ErrInvalidTemplate,
+		},
+		{
+			name: "UnknownFunc",
+			tmpl: Template{Headers: "X-Ref: {{ nope .Ref }}"},
+			err:  ErrInvalidTemplate,
+		},
+		{
+			name:   "ValidForEvents",
+			tmpl:   Template{Body: `{{ .Ref }} {{ .Sender.Username }}`, Headers: "X-Repo: {{ .Repository.Name }}"},
+			events: []Event{EventPush, EventTag, EventBranchTagCreate},
+		},
+		{
+			name:   "UnknownField",
+			tmpl:   Template{Body: "{{ .Nope }}"},
+			events: []Event{EventPush},
+			err:    ErrInvalidTemplate,
+		},
+		{
+			name:   "FieldOfOtherEvent",
+			tmpl:   Template{Body: "{{ .Ref }}"},
+			events: []Event{EventPush, EventCollaborator},
+			err:    ErrInvalidTemplate,
+		},
+		{
+			name:   "ReservedHeader",
+			tmpl:   Template{Headers: "X-SoftServe-Event: nope"},
+			events: []Event{EventUser},
+			err:    ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tmpl.Validate(tt.events...); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTemplateRender(t *testing.T) {
+	tmpl := Template{
+		Body:    `{"ref": {{ .Ref | trimPrefix "refs/heads/" | json }}, "repo": "{{ .Repository.Name | upper }}"}`,
+		Headers: "X-Ref: {{ .Ref }}\n\nX-Repo: {{ .Repository.Name }}\n",
+	}
+
+	body, err := tmpl.renderBody(testPushEvent())
+	if err != nil {
+		t.Fatalf("renderBody() error = %v", err)
+	}
+
+	if want := `{"ref": "main", "repo": "REPO1"}`; body != want {
+		t.Errorf("renderBody() = %q, want %q", body, want)
+	}
+
+	headers, err := tmpl.renderHeaders(testPushEvent())
+	if err != nil {
+		t.Fatalf("renderHeaders() error = %v", err)
+	}
+
+	if got := headers.Get("X-Ref"); got != "refs/heads/main" {
+		t.Errorf("renderHeaders() X-Ref = %q, want %q", got, "refs/heads/main")
+	}
+	if got := headers.Get("X-Repo"); got != "repo1" {
+		t.Errorf("renderHeaders() X-Repo = %q, want %q", got, "repo1")
+	}
+}
+
+func TestTemplateRenderErrors(t *testing.T) {
+	if _, err := (Template{Body: "{{ .Nope }}"}).renderBody(testPushEvent()); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("renderBody() error = %v, want %v", err, ErrInvalidTemplate)
+	}
+
+	for _, headers := range []string{"not a header", "Content-Type: text/plain", "user-agent: nope", "X-SoftServe-Signature: nope"} {
+		if _, err := (Template{Headers: headers}).renderHeaders(testPushEvent()); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("renderHeaders(%q) error = %v, want %v", headers, err, ErrInvalidTemplate)
+		}
+	}
+}
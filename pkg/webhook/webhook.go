@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/db"
@@ -36,6 +39,84 @@ type Delivery struct {
 	Event Event
 }
 
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending is a delivery waiting to be sent or retried.
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusDelivered is a delivery that got a successful response.
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	// DeliveryStatusFailed is a delivery that ran out of attempts.
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+const (
+	// deliveryTimeout is the maximum duration of a single delivery attempt.
+	deliveryTimeout = 30 * time.Second
+
+	// deliveryLease is how long a claimed delivery is held by the server
+	// sending it. A delivery whose attempt wasn't recorded, e.g. because the
+	// server stopped while sending it, is sent again once its lease expires.
+	deliveryLease = 2 * deliveryTimeout
+
+	// backoffBase is the delay before the first retry.
+	backoffBase = 30 * time.Second
+
+	// backoffMax is the maximum delay between retries.
+	backoffMax = time.Hour
+)
+
+// Backoff returns the delay before retrying a delivery that failed the given
+// number of attempts. The delay doubles with every attempt.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	d := backoffBase
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= backoffMax {
+			return backoffMax
+		}
+	}
+
+	return d
+}
+
+// formatHeaders formats HTTP headers as "Key: Value" lines sorted by key.
+func formatHeaders(h http.Header) string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		for _, v := range h[k] {
+			sb.WriteString(k + ": " + v + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// parseHeaders parses headers formatted by formatHeaders.
+func parseHeaders(s string) http.Header {
+	h := http.Header{}
+	for _, line := range strings.Split(s, "\n") {
+		k, v, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		h.Add(k, v)
+	}
+
+	return h
+}
+
 // do sends a webhook.
 // Caller must close the returned body.
 func do(ctx context.Context, url string, method string, headers http.Header, body io.Reader) (*http.Response, error) {
@@ -53,93 +134,277 @@ func do(ctx context.Context, url string, method string, headers http.Header, bod
 	return res, nil
 }
 
-// SendWebhook sends a webhook event.
-func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload interface{}) error {
-	var buf bytes.Buffer
-	dbx := db.FromContext(ctx)
-	datastore := store.FromContext(ctx)
+// request is an encoded and signed webhook request.
+type request struct {
+	id      uuid.UUID
+	url     string
+	method  string
+	headers http.Header
+	body    string
+}
 
+// newRequest encodes and signs a webhook event payload.
+func newRequest(w models.Webhook, event Event, payload EventPayload) (request, error) {
+	var buf bytes.Buffer
+	tmpl := Template{Body: w.BodyTemplate, Headers: w.HeadersTemplate}
 	contentType := ContentType(w.ContentType)
-	switch contentType {
-	case ContentTypeJSON:
+	switch {
+	case tmpl.Body != "":
+		body, err := tmpl.renderBody(payload)
+		if err != nil {
+			return request{}, err
+		}
+		buf.WriteString(body) // nolint: errcheck
+	case contentType == ContentTypeJSON:
 		if err := json.NewEncoder(&buf).Encode(payload); err != nil {
-			return err
+			return request{}, err
 		}
-	case ContentTypeForm:
+	case contentType == ContentTypeForm:
 		v, err := query.Values(payload)
 		if err != nil {
-			return err
+			return request{}, err
 		}
 		buf.WriteString(v.Encode()) // nolint: errcheck
+	case contentType == ContentTypeSlack, contentType == ContentTypeDiscord, contentType == ContentTypeMatrix:
+		b, err := encodeChatPayload(contentType, payload)
+		if err != nil {
+			return request{}, err
+		}
+		buf.Write(b) // nolint: errcheck
 	default:
-		return ErrInvalidContentType
+		return request{}, ErrInvalidContentType
 	}
 
 	headers := http.Header{}
-	headers.Add("Content-Type", contentType.String())
+	headers.Add("Content-Type", contentType.MediaType())
 	headers.Add("User-Agent", "SoftServe/"+version.Version)
 	headers.Add("X-SoftServe-Event", event.String())
 
+	if tmpl.Headers != "" {
+		extra, err := tmpl.renderHeaders(payload)
+		if err != nil {
+			return request{}, err
+		}
+		for k, v := range extra {
+			headers[k] = v
+		}
+	}
+
 	id, err := uuid.NewUUID()
 	if err != nil {
-		return err
+		return request{}, err
 	}
 
-	headers.Add("X-SoftServe-Delivery", id.String())
+	headers.Set("X-SoftServe-Delivery", id.String())
 
-	reqBody := buf.String()
-	if w.Secret != "" {
+	req := request{
+		id:      id,
+		url:     requestURL(w, id),
+		method:  http.MethodPost,
+		headers: headers,
+		body:    buf.String(),
+	}
+	if contentType == ContentTypeMatrix {
+		// Matrix messages are sent to the room send endpoint using the
+		// delivery ID as the transaction ID. The access token is added when
+		// the delivery is sent, see DeliverWebhook.
+		req.method = http.MethodPut
+	} else if w.Secret != "" {
 		sig := hmac.New(sha256.New, []byte(w.Secret))
-		sig.Write([]byte(reqBody)) // nolint: errcheck
-		headers.Add("X-SoftServe-Signature", "sha256="+hex.EncodeToString(sig.Sum(nil)))
+		sig.Write([]byte(req.body)) // nolint: errcheck
+		headers.Set("X-SoftServe-Signature", "sha256="+hex.EncodeToString(sig.Sum(nil)))
+	}
+
+	return req, nil
+}
+
+// SendWebhook queues a webhook event for delivery.
+//
+// The payload is encoded and signed right away, and the delivery is stored as
+// pending. Pending deliveries are sent asynchronously by the server, see
+// DeliverWebhook.
+func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload EventPayload) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	req, err := newRequest(w, event, payload)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(datastore.QueueWebhookDelivery(ctx, dbx, req.id, w.ID, int(event), req.url, req.method, formatHeaders(req.headers), req.body, time.Now()))
+}
+
+// PingWebhook sends a ping event to a webhook right away and returns the ID
+// of the recorded delivery. Failed pings are not retried.
+func PingWebhook(ctx context.Context, w models.Webhook, payload PingEvent) (uuid.UUID, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	req, err := newRequest(w, EventPing, payload)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Schedule the delivery after its lease so that the delivery job doesn't
+	// pick it up while it's being sent.
+	if err := datastore.QueueWebhookDelivery(ctx, dbx, req.id, w.ID, int(EventPing), req.url, req.method, formatHeaders(req.headers), req.body, time.Now().Add(deliveryLease)); err != nil {
+		return uuid.Nil, db.WrapError(err)
+	}
+
+	d, err := datastore.GetWebhookDeliveryByID(ctx, dbx, w.ID, req.id)
+	if err != nil {
+		return uuid.Nil, db.WrapError(err)
+	}
+
+	if err := DeliverWebhook(ctx, d, 1); err != nil {
+		return uuid.Nil, err
+	}
+
+	return req.id, nil
+}
+
+// RedeliverWebhook queues a copy of a previous delivery. The original request
+// is sent again as is with a new delivery ID.
+func RedeliverWebhook(ctx context.Context, w models.Webhook, d models.WebhookDelivery) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
 	}
 
-	res, reqErr := do(ctx, w.URL, http.MethodPost, headers, &buf)
-	var reqHeaders string
-	for k, v := range headers {
-		reqHeaders += k + ": " + v[0] + "\n"
+	headers := parseHeaders(d.RequestHeaders)
+	headers.Set("X-SoftServe-Delivery", id.String())
+
+	url := d.RequestURL
+	if ContentType(w.ContentType) == ContentTypeMatrix {
+		url = requestURL(w, id)
 	}
 
+	return db.WrapError(datastore.QueueWebhookDelivery(ctx, dbx, id, w.ID, d.Event, url, d.RequestMethod, formatHeaders(headers), d.RequestBody, time.Now()))
+}
+
+// requestURL returns the URL a delivery is sent to. Matrix deliveries append
+// the delivery ID as the transaction ID.
+func requestURL(w models.Webhook, id uuid.UUID) string {
+	if ContentType(w.ContentType) == ContentTypeMatrix {
+		return strings.TrimSuffix(w.URL, "/") + "/" + id.String()
+	}
+	return w.URL
+}
+
+// ClaimWebhookDelivery claims a due pending delivery for this server so that
+// other servers sharing the database don't send it at the same time. It
+// reports false if the delivery was already claimed or sent.
+func ClaimWebhookDelivery(ctx context.Context, d models.WebhookDelivery) (bool, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	now := time.Now()
+	claimed, err := datastore.ClaimWebhookDelivery(ctx, dbx, d.ID, now, now.Add(deliveryLease))
+	return claimed, db.WrapError(err)
+}
+
+// DeliverWebhook attempts to send a pending webhook delivery and records the
+// result. Failed attempts are retried with an exponential backoff until
+// maxAttempts is reached, after which the delivery is marked as failed.
+//
+// The secret of Matrix webhooks is the access token of the sending user. It
+// isn't stored with the delivery and is added to the request here.
+func DeliverWebhook(ctx context.Context, d models.WebhookDelivery, maxAttempts int) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	w, err := datastore.GetWebhookByDeliveryID(ctx, dbx, d.ID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	headers := parseHeaders(d.RequestHeaders)
+	if ContentType(w.ContentType) == ContentTypeMatrix && w.Secret != "" {
+		headers.Set("Authorization", "Bearer "+w.Secret)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	res, reqErr := do(reqCtx, d.RequestURL, d.RequestMethod, headers, strings.NewReader(d.RequestBody))
+
 	resStatus := 0
 	resHeaders := ""
 	resBody := ""
 
 	if res != nil {
 		resStatus = res.StatusCode
-		for k, v := range res.Header {
-			resHeaders += k + ": " + v[0] + "\n"
-		}
+		resHeaders = formatHeaders(res.Header)
 
 		if res.Body != nil {
 			defer res.Body.Close() // nolint: errcheck
 			b, err := io.ReadAll(res.Body)
-			if err != nil {
-				return err
+			if err != nil && reqErr == nil {
+				reqErr = err
 			}
 
 			resBody = string(b)
 		}
 	}
 
-	return db.WrapError(datastore.CreateWebhookDelivery(ctx, dbx, id, w.ID, int(event), w.URL, http.MethodPost, reqErr, reqHeaders, reqBody, resStatus, resHeaders, resBody))
+	// An attempt aborted because the server is stopping doesn't count, the
+	// delivery is sent again once its lease expires.
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	attempts := d.Attempts + 1
+	status := DeliveryStatusDelivered
+	var next *time.Time
+	if reqErr != nil || resStatus < 200 || resStatus >= 300 {
+		status = DeliveryStatusFailed
+		if attempts < maxAttempts {
+			status = DeliveryStatusPending
+			t := time.Now().Add(Backoff(attempts))
+			next = &t
+		}
+	}
+
+	return db.WrapError(datastore.UpdateWebhookDeliveryAttempt(ctx, dbx, d.ID, string(status), attempts, next, reqErr, resStatus, resHeaders, resBody))
 }
 
 // SendEvent sends a webhook event.
 func SendEvent(ctx context.Context, payload EventPayload) error {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
-	webhooks, err := datastore.GetWebhooksByRepoIDWhereEvent(ctx, dbx, payload.RepositoryID(), []int{int(payload.Event())})
+	events := []int{int(payload.Event())}
+
+	var webhooks []models.Webhook
+	if id := payload.RepositoryID(); id != 0 {
+		whs, err := datastore.GetWebhooksByRepoIDWhereEvent(ctx, dbx, id, events)
+		if err != nil {
+			return db.WrapError(err)
+		}
+		webhooks = append(webhooks, whs...)
+	}
+
+	// System webhooks receive the events of every repository and server
+	// events.
+	whs, err := datastore.GetSystemWebhooksWhereEvent(ctx, dbx, events)
 	if err != nil {
 		return db.WrapError(err)
 	}
+	webhooks = append(webhooks, whs...)
 
+	// A webhook that can't be sent, e.g. because its template fails to
+	// render, doesn't prevent sending the others.
+	var errs []error
 	for _, w := range webhooks {
 		if err := SendWebhook(ctx, w, payload.Event(), payload); err != nil {
-			return err
+			errs = append(errs, fmt.Errorf("webhook %d: %w", w.ID, err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func repoURL(publicURL string, repo string) string {
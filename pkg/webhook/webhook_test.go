@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 0},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestFormatParseHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Add("Content-Type", "application/json")
+	h.Add("X-SoftServe-Event", "push")
+	h.Add("X-SoftServe-Signature", "sha256=abc: def")
+
+	s := formatHeaders(h)
+	want := "Content-Type: application/json\nX-Softserve-Event: push\nX-Softserve-Signature: sha256=abc: def\n"
+	if s != want {
+		t.Fatalf("formatHeaders() = %q, want %q", s, want)
+	}
+
+	got := parseHeaders(s)
+	for k := range h {
+		if got.Get(k) != h.Get(k) {
+			t.Errorf("parseHeaders() %s = %q, want %q", k, got.Get(k), h.Get(k))
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -81,19 +82,30 @@ func TestScript(t *testing.T) {
 		Cmds: map[string]func(ts *testscript.TestScript, neg bool, args []string){
 			"soft":          cmdSoft("admin", admin1.Signer()),
 			"usoft":         cmdSoft("user1", user1.Signer()),
+			"softin":        cmdSoftin("admin", admin1.Signer()),
+			"softwait":      cmdSoftwait("admin", admin1.Signer()),
 			"git":           cmdGit(admin1Key),
 			"ugit":          cmdGit(user1Key),
 			"curl":          cmdCurl,
 			"mkfile":        cmdMkfile,
 			"envfile":       cmdEnvfile,
+			"envmatch":      cmdEnvmatch,
 			"readfile":      cmdReadfile,
 			"dos2unix":      cmdDos2Unix,
 			"new-webhook":   cmdNewWebhook,
 			"waitforserver": cmdWaitforserver,
+			"waitforfile":   cmdWaitforfile,
 			"stopserver":    cmdStopserver,
 			"ui":            cmdUI(admin1.Signer()),
 			"uui":           cmdUI(user1.Signer()),
 		},
+		Condition: func(cond string) (bool, error) {
+			switch cond {
+			case "postgres":
+				return os.Getenv("SOFT_SERVE_DB_DRIVER") == "postgres", nil
+			}
+			return false, fmt.Errorf("unknown condition %q", cond)
+		},
 		Setup: func(e *testscript.Env) error {
 			// Add binPath to PATH
 			e.Setenv("PATH", fmt.Sprintf("%s%c%s", filepath.Dir(binPath), os.PathListSeparator, e.Getenv("PATH")))
@@ -111,6 +123,7 @@ func TestScript(t *testing.T) {
 
 			e.Setenv("DATA_PATH", data)
 			e.Setenv("SSH_PORT", fmt.Sprintf("%d", sshPort))
+			e.Setenv("GIT_PORT", fmt.Sprintf("%d", gitPort))
 			e.Setenv("HTTP_PORT", fmt.Sprintf("%d", httpPort))
 			e.Setenv("ADMIN1_AUTHORIZED_KEY", admin1.AuthorizedKey())
 			e.Setenv("ADMIN2_AUTHORIZED_KEY", admin2.AuthorizedKey())
@@ -202,6 +215,103 @@ func cmdSoft(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bo
 	}
 }
 
+// cmdSoftin runs a soft command with the contents of a file as its standard
+// input.
+//
+// Usage: softin FILE ARGS...
+func cmdSoftin(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
+	return func(ts *testscript.TestScript, neg bool, args []string) {
+		if len(args) < 2 {
+			ts.Fatalf("usage: softin FILE ARGS...")
+			return
+		}
+
+		cli, err := ssh.Dial(
+			"tcp",
+			net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
+			&ssh.ClientConfig{
+				User:            user,
+				Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			},
+		)
+		ts.Check(err)
+		defer cli.Close()
+
+		sess, err := cli.NewSession()
+		ts.Check(err)
+		defer sess.Close()
+
+		f, err := os.Open(ts.MkAbs(args[0]))
+		ts.Check(err)
+		defer f.Close()
+
+		sess.Stdin = f
+		sess.Stdout = ts.Stdout()
+		sess.Stderr = ts.Stderr()
+
+		check(ts, sess.Run(strings.Join(args[1:], " ")), neg)
+	}
+}
+
+// softwaitTimeout is the maximum duration softwait waits for a match.
+const softwaitTimeout = 15 * time.Second
+
+// cmdSoftwait runs a soft command until its standard output matches a regular
+// expression. It's used to wait for the server background jobs. The output
+// of the last run is the command output.
+//
+// Usage: softwait REGEXP ARGS...
+func cmdSoftwait(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
+	return func(ts *testscript.TestScript, neg bool, args []string) {
+		if neg {
+			ts.Fatalf("unsupported: ! softwait")
+		}
+		if len(args) < 2 {
+			ts.Fatalf("usage: softwait REGEXP ARGS...")
+		}
+
+		re, err := regexp.Compile(args[0])
+		ts.Check(err)
+
+		deadline := time.Now().Add(softwaitTimeout)
+		for {
+			var stdout, stderr bytes.Buffer
+			cli, err := ssh.Dial(
+				"tcp",
+				net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
+				&ssh.ClientConfig{
+					User:            user,
+					Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+					HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+				},
+			)
+			ts.Check(err)
+
+			sess, err := cli.NewSession()
+			ts.Check(err)
+
+			sess.Stdout = &stdout
+			sess.Stderr = &stderr
+			err = sess.Run(strings.Join(args[1:], " "))
+			sess.Close() // nolint: errcheck
+			cli.Close()  // nolint: errcheck
+
+			if err == nil && re.Match(stdout.Bytes()) {
+				ts.Stdout().Write(stdout.Bytes()) // nolint: errcheck
+				ts.Stderr().Write(stderr.Bytes()) // nolint: errcheck
+				return
+			}
+
+			if time.Now().After(deadline) {
+				ts.Fatalf("no match for %q after %s, last output: %q, error: %v", args[0], softwaitTimeout, stdout.String()+stderr.String(), err)
+			}
+
+			time.Sleep(250 * time.Millisecond)
+		}
+	}
+}
+
 func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
 	return func(ts *testscript.TestScript, neg bool, args []string) {
 		if len(args) < 1 {
@@ -360,6 +470,26 @@ func cmdEnvfile(ts *testscript.TestScript, neg bool, args []string) {
 	}
 }
 
+// cmdEnvmatch sets an environment variable to the first submatch of a regular
+// expression in the standard output of the previous command.
+//
+// Usage: envmatch KEY REGEXP
+func cmdEnvmatch(ts *testscript.TestScript, neg bool, args []string) {
+	if len(args) != 2 {
+		ts.Fatalf("usage: envmatch KEY REGEXP")
+	}
+
+	re, err := regexp.Compile(args[1])
+	ts.Check(err)
+
+	m := re.FindStringSubmatch(ts.ReadFile("stdout"))
+	if len(m) < 2 {
+		ts.Fatalf("no match for %q found in stdout", args[1])
+	}
+
+	ts.Setenv(args[0], m[1])
+}
+
 func cmdNewWebhook(ts *testscript.TestScript, neg bool, args []string) {
 	type webhookSite struct {
 		UUID string `json:"uuid"`
@@ -486,6 +616,30 @@ func cmdWaitforserver(ts *testscript.TestScript, neg bool, args []string) {
 	}
 }
 
+// cmdWaitforfile waits until a file exists, or with ! until it doesn't.
+//
+// Usage: [!] waitforfile PATH
+func cmdWaitforfile(ts *testscript.TestScript, neg bool, args []string) {
+	if len(args) != 1 {
+		ts.Fatalf("usage: [!] waitforfile PATH")
+	}
+
+	path := ts.MkAbs(args[0])
+	deadline := time.Now().Add(softwaitTimeout)
+	for {
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != neg {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			ts.Fatalf("timed out waiting for %s", args[0])
+		}
+
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
 func cmdStopserver(ts *testscript.TestScript, neg bool, args []string) {
 	// stop the server
 	resp, err := http.DefaultClient.Head(fmt.Sprintf("%s/__stop", ts.Getenv("SOFT_SERVE_HTTP_PUBLIC_URL")))